@@ -0,0 +1,58 @@
+package app
+
+import (
+	"mcp-go-tutorials/pkg/log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// logLevelRequest 修改日志级别的请求，modules 不为空时替换所有模块级别覆盖
+type logLevelRequest struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// registerAdmin 按配置注册管理接口
+func registerAdmin(router gin.IRouter) {
+	if !cfg.Admin.Enabled {
+		return
+	}
+	group := router.Group(cfg.Admin.Path, tokenAuthMiddleware(cfg.Admin.Token))
+	group.GET("/log/level", getLogLevelHandler)
+	group.PUT("/log/level", setLogLevelHandler)
+	log.Infof("Admin endpoints enabled at %s", cfg.Admin.Path)
+}
+
+func getLogLevelHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"level":   log.GetLevel(),
+		"modules": log.ModuleLevels(),
+	})
+}
+
+func setLogLevelHandler(c *gin.Context) {
+	var req logLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	logLevelMu.Lock()
+	defer logLevelMu.Unlock()
+	if req.Level != "" {
+		if err := log.SetLevel(req.Level); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resetDebugToggle()
+	}
+	if req.Modules != nil {
+		if err := log.SetModuleLevels(req.Modules); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	log.Infof("Log level changed via admin endpoint: level=%s modules=%v", log.GetLevel(), log.ModuleLevels())
+	getLogLevelHandler(c)
+}
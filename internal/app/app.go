@@ -1,11 +1,9 @@
 package app
 
 import (
-	"mcp-go-tutorials/pkg/log"
 	"mcp-go-tutorials/pkg/version/verflag"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 type TransportMode string
@@ -16,44 +14,59 @@ const (
 	HTTPMode  TransportMode = "streamableHttp"
 )
 
-type Config struct {
-	Mode     string `mapstructure:"mode"`
-	Port     string `mapstructure:"port"`
-	LogLevel string `mapstructure:"log_level"`
-	GinMode  string `mapstructure:"gin_mode"`
-}
-
-var (
-	cfgFile string
-	cfg     Config
-)
-
 func NewAppCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "mcp-server",
 		Short:        "MCP Server with multiple transport modes",
 		Long:         `MCP Server supporting stdio, sse, and streamableHttp transport modes`,
 		SilenceUsage: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// 如果 `--version=true`，则打印版本并退出
 			verflag.PrintAndExitIfRequested()
-			options := log.NewOptions()
-			log.Init(options)
+			// 子命令的 stdout 用于输出结果，日志改为输出到 stderr
+			return initConfig(cmd != cmd.Root())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
 			runServer()
 			return nil
 		},
 	}
-	cobra.OnInitialize(initConfig)
 	cmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is ./config.yaml)")
 	cmd.PersistentFlags().StringP("mode", "m", "streamableHttp", "Transport mode: stdio, sse, http")
 	cmd.PersistentFlags().StringP("port", "p", "8081", "Port for HTTP/SSE server")
-	cmd.PersistentFlags().String("log-level", "info", "Log level: debug, info, warn, error")
+	cmd.PersistentFlags().String("listen", "", "Listen address for HTTP/SSE server, e.g. unix:///run/mcp.sock or tcp://127.0.0.1:8081; overrides --port")
+	cmd.PersistentFlags().String("log-backend", "logrus", "Log backend: logrus, slog")
+	cmd.PersistentFlags().String("log-level", "info", "Log level: debug, info, warn, error, panic, fatal")
+	cmd.PersistentFlags().String("log-format", "text", "Log format: text, json")
+	cmd.PersistentFlags().String("log-output", "stdout", "Log output: stdout, stderr, file, both")
+	cmd.PersistentFlags().String("log-file", "", "Log file path, used when log output is file or both")
+	cmd.PersistentFlags().String("log-time-format", "human", "Log time format: iso8601, human, with-ms, compact, rfc3339, rfc1123")
+	cmd.PersistentFlags().Bool("log-caller", true, "Report the file and line of the caller in logs")
+	cmd.PersistentFlags().StringSlice("log-module", nil, "Per-module log level override in module=level form, e.g. tool.calculate=debug")
+	cmd.PersistentFlags().Bool("admin", false, "Enable the admin endpoints for runtime log level changes")
 	cmd.PersistentFlags().String("gin-mode", "release", "Gin mode: debug, release, test")
+	cmd.PersistentFlags().Bool("console", false, "Enable the built-in web console for invoking tools")
 
 	// 绑定 Viper
-	_ = viper.BindPFlag("mode", cmd.PersistentFlags().Lookup("mode"))
-	_ = viper.BindPFlag("port", cmd.PersistentFlags().Lookup("port"))
-	_ = viper.BindPFlag("gin_mode", cmd.PersistentFlags().Lookup("gin-mode"))
+	bindFlag("mode", cmd.PersistentFlags().Lookup("mode"))
+	bindFlag("port", cmd.PersistentFlags().Lookup("port"))
+	bindFlag("listen", cmd.PersistentFlags().Lookup("listen"))
+	bindFlag("gin_mode", cmd.PersistentFlags().Lookup("gin-mode"))
+	bindFlag("log.backend", cmd.PersistentFlags().Lookup("log-backend"))
+	bindFlag("log.level", cmd.PersistentFlags().Lookup("log-level"))
+	bindFlag("log.format", cmd.PersistentFlags().Lookup("log-format"))
+	bindFlag("log.output", cmd.PersistentFlags().Lookup("log-output"))
+	bindFlag("log.filepath", cmd.PersistentFlags().Lookup("log-file"))
+	bindFlag("log.timeFormat", cmd.PersistentFlags().Lookup("log-time-format"))
+	bindFlag("log.enableCaller", cmd.PersistentFlags().Lookup("log-caller"))
+	bindFlag("log.modules", cmd.PersistentFlags().Lookup("log-module"))
+	bindFlag("admin.enabled", cmd.PersistentFlags().Lookup("admin"))
+	bindFlag("console.enabled", cmd.PersistentFlags().Lookup("console"))
 	verflag.AddFlags(cmd.PersistentFlags())
+
+	cmd.AddCommand(newToolsCmd(), newClientCmd(), newReplCmd(), newConfigCmd())
 	return cmd
 }
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/mcpclient"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+// clientFlags client 子命令共享的连接参数
+type clientFlags struct {
+	transport string
+	url       string
+	command   string
+	env       []string
+	headers   []string
+	timeout   time.Duration
+}
+
+// addFlags 在 FlagSet 上注册连接参数
+func (f *clientFlags) addFlags(cmd *cobra.Command) {
+	fs := cmd.PersistentFlags()
+	fs.StringVarP(&f.transport, "transport", "t", "", "Transport: stdio, sse, streamableHttp (default inferred from --command/--url)")
+	fs.StringVarP(&f.url, "url", "u", "", "Server URL for sse/streamableHttp, e.g. http://localhost:8081/mcp")
+	fs.StringVar(&f.command, "command", "", "Command line to spawn for stdio, e.g. \"./mcp-server --mode stdio\"; quote arguments or paths containing spaces")
+	fs.StringArrayVar(&f.env, "env", nil, "Extra KEY=VALUE environment variable for the stdio command, may be repeated")
+	fs.StringArrayVar(&f.headers, "header", nil, "Extra Key=Value HTTP header, may be repeated")
+	fs.DurationVar(&f.timeout, "timeout", 30*time.Second, "Timeout for each request")
+}
+
+// options 将命令行参数转换为连接选项
+func (f *clientFlags) options() (mcpclient.Options, error) {
+	headers, err := parseKeyValues(f.headers)
+	if err != nil {
+		return mcpclient.Options{}, fmt.Errorf("invalid --header: %w", err)
+	}
+	return mcpclient.Options{
+		Transport: f.transport,
+		URL:       f.url,
+		Command:   f.command,
+		Env:       f.env,
+		Headers:   headers,
+		Timeout:   f.timeout,
+	}, nil
+}
+
+// connect 连接服务器并完成 initialize
+func (f *clientFlags) connect(ctx context.Context) (*mcpclient.Client, error) {
+	opts, err := f.options()
+	if err != nil {
+		return nil, err
+	}
+	return mcpclient.Connect(ctx, opts)
+}
+
+// newClientCmd 创建 client 子命令，用于连接远程 MCP 服务器进行冒烟测试
+func newClientCmd() *cobra.Command {
+	flags := &clientFlags{}
+	cmd := &cobra.Command{
+		Use:   "client",
+		Short: "Connect to an MCP server over stdio, sse or streamableHttp",
+		Example: `  mcp-server client info --url http://localhost:8081/mcp
+  mcp-server client tools list --transport sse --url http://localhost:8081/sse
+  mcp-server client tools call calculate --command "./mcp-server --mode stdio" --arg operation=add --arg x=1 --arg y=2`,
+	}
+	flags.addFlags(cmd)
+	cmd.AddCommand(
+		newClientInfoCmd(flags),
+		newClientToolsCmd(flags),
+		newClientResourcesCmd(flags),
+		newClientPromptsCmd(flags),
+	)
+	return cmd
+}
+
+// runClient 建立连接后执行 fn，并以 JSON 输出其结果
+func runClient(cmd *cobra.Command, flags *clientFlags, fn func(ctx context.Context, c *mcpclient.Client) (any, error)) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c, err := flags.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	reqCtx, cancel := c.RequestContext(ctx)
+	defer cancel()
+	result, err := fn(reqCtx, c)
+	if err != nil {
+		return err
+	}
+	return printJSON(cmd.OutOrStdout(), result)
+}
+
+func newClientInfoCmd(flags *clientFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "info",
+		Short: "Print the server's initialize result",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClient(cmd, flags, func(_ context.Context, c *mcpclient.Client) (any, error) {
+				return c.ServerInfo, nil
+			})
+		},
+	}
+}
+
+func newClientToolsCmd(flags *clientFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "List and call tools on the server",
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List tools",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClient(cmd, flags, func(ctx context.Context, c *mcpclient.Client) (any, error) {
+				return c.ListTools(ctx, mcp.ListToolsRequest{})
+			})
+		},
+	}
+
+	var (
+		rawArgs  []string
+		jsonArgs string
+	)
+	call := &cobra.Command{
+		Use:   "call <name>",
+		Short: "Call a tool",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var isError bool
+			err := runClient(cmd, flags, func(ctx context.Context, c *mcpclient.Client) (any, error) {
+				schema, err := c.FindTool(ctx, args[0])
+				if err != nil {
+					return nil, err
+				}
+				arguments, err := parseToolArguments(*schema, jsonArgs, rawArgs)
+				if err != nil {
+					return nil, err
+				}
+
+				request := mcp.CallToolRequest{}
+				request.Params.Name = args[0]
+				request.Params.Arguments = arguments
+				result, err := c.CallTool(ctx, request)
+				if err != nil {
+					return nil, err
+				}
+				isError = result.IsError
+				return result, nil
+			})
+			if err == nil && isError {
+				return fmt.Errorf("tool %s returned an error", args[0])
+			}
+			return err
+		},
+	}
+	call.Flags().StringArrayVar(&rawArgs, "arg", nil, "Tool argument in key=value form, may be repeated")
+	call.Flags().StringVar(&jsonArgs, "json", "", "Tool arguments as a JSON object, merged before --arg values")
+
+	cmd.AddCommand(list, call)
+	return cmd
+}
+
+func newClientResourcesCmd(flags *clientFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resources",
+		Short: "List and read resources on the server",
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List resources",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClient(cmd, flags, func(ctx context.Context, c *mcpclient.Client) (any, error) {
+				return c.ListResources(ctx, mcp.ListResourcesRequest{})
+			})
+		},
+	}
+
+	read := &cobra.Command{
+		Use:   "read <uri>",
+		Short: "Read a resource",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClient(cmd, flags, func(ctx context.Context, c *mcpclient.Client) (any, error) {
+				request := mcp.ReadResourceRequest{}
+				request.Params.URI = args[0]
+				return c.ReadResource(ctx, request)
+			})
+		},
+	}
+
+	cmd.AddCommand(list, read)
+	return cmd
+}
+
+func newClientPromptsCmd(flags *clientFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prompts",
+		Short: "List and get prompts on the server",
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List prompts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClient(cmd, flags, func(ctx context.Context, c *mcpclient.Client) (any, error) {
+				return c.ListPrompts(ctx, mcp.ListPromptsRequest{})
+			})
+		},
+	}
+
+	var rawArgs []string
+	get := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Get a prompt",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			arguments, err := parseKeyValues(rawArgs)
+			if err != nil {
+				return fmt.Errorf("invalid --arg: %w", err)
+			}
+			return runClient(cmd, flags, func(ctx context.Context, c *mcpclient.Client) (any, error) {
+				request := mcp.GetPromptRequest{}
+				request.Params.Name = args[0]
+				request.Params.Arguments = arguments
+				return c.GetPrompt(ctx, request)
+			})
+		},
+	}
+	get.Flags().StringArrayVar(&rawArgs, "arg", nil, "Prompt argument in key=value form, may be repeated")
+
+	cmd.AddCommand(list, get)
+	return cmd
+}
+
+// parseKeyValues 将 key=value 形式的参数解析为 map
+func parseKeyValues(values []string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for _, raw := range values {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%q is not in key=value form", raw)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
@@ -0,0 +1,353 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	stdlog "log"
+	"log/slog"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/pkg/log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// 配置项的来源
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// envPrefix 环境变量前缀
+const envPrefix = "MCP"
+
+type Config struct {
+	Mode       string        `mapstructure:"mode"`
+	Port       string        `mapstructure:"port"`
+	Listen     string        `mapstructure:"listen"`      // unix:///run/mcp.sock 或 tcp://127.0.0.1:8081，配置后替代 port
+	SocketPerm string        `mapstructure:"socket_perm"` // Unix socket 文件的权限
+	GinMode    string        `mapstructure:"gin_mode"`
+	Server     ServerConfig  `mapstructure:"server"`
+	Routes     RoutesConfig  `mapstructure:"routes"`
+	Console    ConsoleConfig `mapstructure:"console"`
+	Admin      AdminConfig   `mapstructure:"admin"`
+	TLS        TLSConfig     `mapstructure:"tls"`
+	Log        log.Options   `mapstructure:"log"`
+	Tools      ToolsConfig   `mapstructure:"tools"`
+}
+
+// ConsoleConfig Web 控制台配置
+type ConsoleConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+	Token   string `mapstructure:"token"` // 控制台接口需携带 Authorization: Bearer <token>，在页面中输入
+}
+
+// AdminConfig 管理接口配置
+type AdminConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`
+	Token   string `mapstructure:"token"` // 请求需携带 Authorization: Bearer <token>
+}
+
+// scheme 返回服务监听的 URL scheme
+func (c *Config) scheme() string {
+	if c.TLS.Enabled {
+		return "https"
+	}
+	return "http"
+}
+
+// ToolsConfig 工具配置
+type ToolsConfig struct {
+	Disabled   []string         `mapstructure:"disabled"` // 禁用的工具名称
+	Filesystem FilesystemConfig `mapstructure:"filesystem"`
+}
+
+// FilesystemConfig 文件系统工具配置，未配置根目录时不注册文件系统工具
+type FilesystemConfig struct {
+	Roots        []string `mapstructure:"roots"`          // 允许访问的目录
+	ReadOnly     bool     `mapstructure:"read_only"`      // 只读模式，不注册 write_file 和 move
+	MaxFileBytes int64    `mapstructure:"max_file_bytes"` // 读写单个文件的最大字节数
+}
+
+// FieldError 单个配置项的校验错误
+type FieldError struct {
+	Key     string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Key + ": " + e.Message
+}
+
+// ValidationError 汇总所有配置项的校验错误
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid configuration:")
+	for _, fe := range e {
+		b.WriteString("\n  - ")
+		b.WriteString(fe.Error())
+	}
+	return b.String()
+}
+
+var (
+	cfgFile string
+	cfg     Config
+
+	// flagBindings 记录配置项与命令行参数的绑定关系，用于判断配置来源
+	flagBindings = make(map[string]*pflag.Flag)
+
+	// envBindings 驼峰命名的配置项对应的环境变量，其余配置项按 MCP_<KEY> 自动映射
+	envBindings = map[string]string{
+		"log.timeFormat":    "MCP_LOG_TIME_FORMAT",
+		"log.maxSize":       "MCP_LOG_MAX_SIZE",
+		"log.maxBackups":    "MCP_LOG_MAX_BACKUPS",
+		"log.maxAge":        "MCP_LOG_MAX_AGE",
+		"log.enableCaller":  "MCP_LOG_ENABLE_CALLER",
+		"log.disableStdout": "MCP_LOG_DISABLE_STDOUT",
+	}
+)
+
+// bindFlag 将命令行参数绑定到配置项
+func bindFlag(key string, flag *pflag.Flag) {
+	flagBindings[strings.ToLower(key)] = flag
+	_ = viper.BindPFlag(key, flag)
+}
+
+// loadConfig 读取配置文件、环境变量和命令行参数并解析到 cfg
+func loadConfig() error {
+	setDefaultValue()
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+	} else {
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("/app")
+		viper.SetConfigType("yaml")
+		viper.SetConfigName("config.yaml")
+	}
+
+	// read in environment variables that match
+	viper.AutomaticEnv()
+	// 读取环境变量的前缀小写,将自动转变为大写。
+	viper.SetEnvPrefix(envPrefix)
+
+	//将 viper.Get(key) key 字符串中 '.' 和 '-' 替换为 '_'
+	replacer := strings.NewReplacer(".", "_", "-", "_")
+	viper.SetEnvKeyReplacer(replacer)
+	for key, env := range envBindings {
+		_ = viper.BindEnv(key, env)
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		// 未显式指定配置文件且默认位置不存在时使用默认值，其余情况均视为错误
+		var notFound viper.ConfigFileNotFoundError
+		if cfgFile != "" || !errors.As(err, &notFound) {
+			return fmt.Errorf("read config file: %w", err)
+		}
+	}
+
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return fmt.Errorf("decode config: %w", err)
+	}
+	return nil
+}
+
+// initConfig 加载配置并初始化日志，之后的所有输出都经过已配置的日志
+func initConfig(useStderr bool) error {
+	if err := loadConfig(); err != nil {
+		return err
+	}
+
+	// 日志选项必须先于其他配置校验，否则无法初始化日志
+	if errs := logValidationErrors(&cfg.Log); len(errs) > 0 {
+		return errs
+	}
+	options := cfg.Log
+	// stdio 模式下 stdout 是 JSON-RPC 通道，控制台日志只能输出到 stderr
+	options.UseStderr = useStderr || TransportMode(cfg.Mode) == StdioMode
+	log.Init(&options)
+	// 第三方库通过 slog 输出的日志也转发到已配置的日志
+	slog.SetDefault(slog.New(log.NewSlogHandler("")))
+	// slog.SetDefault 同时会接管标准库 log，恢复它的默认输出，避免命令行的致命错误变成 INFO 日志
+	stdlog.SetOutput(os.Stderr)
+	stdlog.SetFlags(stdlog.LstdFlags)
+
+	// 设置 Gin 模式
+	gin.SetMode(cfg.GinMode)
+	if file := viper.ConfigFileUsed(); file != "" {
+		log.Infof("Using config file: %s", file)
+	}
+	return nil
+}
+
+func setDefaultValue() {
+	// 设置默认值
+	viper.SetDefault("mode", "streamableHttp")
+	viper.SetDefault("port", "8081")
+	viper.SetDefault("listen", "")
+	viper.SetDefault("socket_perm", "0660")
+	viper.SetDefault("gin_mode", "release")
+	viper.SetDefault("server.read_timeout", 30*time.Second)
+	viper.SetDefault("server.read_header_timeout", 10*time.Second)
+	viper.SetDefault("server.write_timeout", 0)
+	viper.SetDefault("server.idle_timeout", 120*time.Second)
+	viper.SetDefault("server.max_body_bytes", 4<<20)
+	viper.SetDefault("server.max_header_bytes", 1<<20)
+	viper.SetDefault("server.check_origin", true)
+	viper.SetDefault("server.cors.allowed_origins", []string{})
+	viper.SetDefault("server.cors.allowed_headers", []string{"Content-Type", "Authorization", "Mcp-Session-Id", "Mcp-Protocol-Version", "Last-Event-ID"})
+	viper.SetDefault("server.cors.exposed_headers", []string{"Mcp-Session-Id"})
+	viper.SetDefault("server.cors.max_age", 10*time.Minute)
+	viper.SetDefault("routes.base_path", "")
+	viper.SetDefault("routes.base_url", "")
+	viper.SetDefault("routes.trust_forwarded_headers", false)
+	viper.SetDefault("routes.mcp", "/mcp")
+	viper.SetDefault("routes.sse", "/sse")
+	viper.SetDefault("routes.message", "/message")
+	viper.SetDefault("routes.health", "/health")
+	viper.SetDefault("routes.metrics", "/metrics")
+	viper.SetDefault("console.enabled", false)
+	viper.SetDefault("console.path", "/console")
+	viper.SetDefault("console.token", "")
+	viper.SetDefault("admin.enabled", false)
+	viper.SetDefault("admin.path", "/admin")
+	viper.SetDefault("admin.token", "")
+	viper.SetDefault("tools.disabled", []string{})
+	viper.SetDefault("tools.filesystem.roots", []string{})
+	viper.SetDefault("tools.filesystem.read_only", false)
+	viper.SetDefault("tools.filesystem.max_file_bytes", 1<<20)
+	//设置日志默认值
+	viper.SetDefault("tls.enabled", false)
+	viper.SetDefault("tls.cert_file", "")
+	viper.SetDefault("tls.key_file", "")
+	viper.SetDefault("tls.min_version", "1.2")
+	viper.SetDefault("tls.cipher_suites", []string{})
+	viper.SetDefault("tls.client_ca_file", "")
+	viper.SetDefault("tls.client_auth", ClientAuthNone)
+	viper.SetDefault("log.backend", log.BackendLogrus)
+	viper.SetDefault("log.level", "info")
+	viper.SetDefault("log.output", "stdout")
+	viper.SetDefault("log.format", "text")
+	viper.SetDefault("log.enableCaller", true)
+	viper.SetDefault("log.disableStdout", false)
+	viper.SetDefault("log.maxSize", 10)
+	viper.SetDefault("log.maxBackups", 3)
+	viper.SetDefault("log.maxAge", 7)
+	viper.SetDefault("log.timeFormat", "human")
+	viper.SetDefault("log.modules", []string{})
+	viper.SetDefault("log.outputs", []log.OutputOptions{})
+}
+
+// Validate 校验配置，返回所有不合法的配置项
+func (c *Config) Validate() error {
+	var errs ValidationError
+	add := func(key string, format string, args ...any) {
+		errs = append(errs, FieldError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	modes := []string{string(StdioMode), string(SSEMode), string(HTTPMode)}
+	if !slices.Contains(modes, c.Mode) {
+		add("mode", "unknown value %q, must be one of %s", c.Mode, strings.Join(modes, ", "))
+	}
+
+	// port 只在网络模式下且未配置 listen 时使用
+	if TransportMode(c.Mode) != StdioMode && c.Listen == "" {
+		if port, err := strconv.Atoi(c.Port); err != nil {
+			add("port", "%q is not a number", c.Port)
+		} else if port < 1 || port > 65535 {
+			add("port", "%d is out of range 1-65535", port)
+		}
+	}
+
+	errs = append(errs, validateListen(c)...)
+
+	ginModes := []string{gin.DebugMode, gin.ReleaseMode, gin.TestMode}
+	if !slices.Contains(ginModes, c.GinMode) {
+		add("gin_mode", "unknown value %q, must be one of %s", c.GinMode, strings.Join(ginModes, ", "))
+	}
+
+	if c.Console.Enabled && !strings.HasPrefix(c.Console.Path, "/") {
+		add("console.path", "%q must start with /", c.Console.Path)
+	}
+	if c.Console.Enabled && c.Console.Token == "" {
+		add("console.token", "is required when the web console is enabled")
+	}
+
+	if c.Admin.Enabled && !strings.HasPrefix(c.Admin.Path, "/") {
+		add("admin.path", "%q must start with /", c.Admin.Path)
+	}
+	if c.Admin.Enabled && c.Admin.Token == "" {
+		add("admin.token", "is required when the admin endpoints are enabled")
+	}
+
+	errs = append(errs, validateServer(c.Server)...)
+	errs = append(errs, validateRoutes(c.Routes)...)
+	errs = append(errs, validateTLS(c.TLS)...)
+	errs = append(errs, logValidationErrors(&c.Log)...)
+
+	for i, root := range c.Tools.Filesystem.Roots {
+		if err := sandbox.CheckRoot(root); err != nil {
+			add(fmt.Sprintf("tools.filesystem.roots[%d]", i), "%v", err)
+		}
+	}
+	if c.Tools.Filesystem.MaxFileBytes <= 0 {
+		add("tools.filesystem.max_file_bytes", "must be positive, got %d", c.Tools.Filesystem.MaxFileBytes)
+	}
+
+	known := make([]string, 0)
+	for _, handler := range append(builtinTools(), filesystemTools(FilesystemConfig{})...) {
+		known = append(known, handler.Name())
+	}
+	for i, name := range c.Tools.Disabled {
+		if !slices.Contains(known, name) {
+			add(fmt.Sprintf("tools.disabled[%d]", i), "unknown tool %q, must be one of %s", name, strings.Join(known, ", "))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// logValidationErrors 将日志选项的校验错误转换为配置项错误
+func logValidationErrors(o *log.Options) ValidationError {
+	var errs ValidationError
+	for _, fe := range o.Validate() {
+		errs = append(errs, FieldError{Key: "log." + fe.Key, Message: fe.Message})
+	}
+	return errs
+}
+
+// configSource 判断配置项的生效来源，优先级为 flag > env > file > default
+func configSource(key string) string {
+	key = strings.ToLower(key)
+	if flag, ok := flagBindings[key]; ok && flag.Changed {
+		return SourceFlag
+	}
+	envKey := envPrefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+	for k, env := range envBindings {
+		if strings.EqualFold(k, key) {
+			envKey = env
+		}
+	}
+	if _, ok := os.LookupEnv(envKey); ok {
+		return SourceEnv
+	}
+	if viper.InConfig(key) {
+		return SourceFile
+	}
+	return SourceDefault
+}
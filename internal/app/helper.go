@@ -1,24 +1,94 @@
 package app
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"log/slog"
+	"mcp-go-tutorials/internal/pkg/console"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/internal/pkg/tool"
 	"mcp-go-tutorials/internal/pkg/tool/impl"
 	"mcp-go-tutorials/internal/pkg/tool/manager"
 	"mcp-go-tutorials/pkg/log"
 	"net/http"
-	"strings"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mark3labs/mcp-go/server"
-	"github.com/spf13/viper"
 )
 
+// builtinTools 返回所有内置工具
+func builtinTools() []tool.Handler {
+	return []tool.Handler{
+		impl.NewCalculatorTool(),
+		impl.NewEvaluateTool(),
+		impl.NewStatisticsTool(),
+		impl.NewConvertUnitsTool(),
+		impl.NewCurrentTimeTool(),
+		impl.NewParseDateTool(),
+		impl.NewConvertTimezoneTool(),
+		impl.NewFormatDateTool(),
+		impl.NewDateMathTool(),
+		impl.NewBusinessDaysTool(),
+		impl.NewStringReverseTool(),
+		impl.NewChangeCaseTool(),
+		impl.NewNormalizeUnicodeTool(),
+		impl.NewSlugifyTool(),
+		impl.NewTextStatsTool(),
+		impl.NewTrimTextTool(),
+		impl.NewWrapTextTool(),
+		impl.NewEncodeTool(),
+		impl.NewDecodeTool(),
+		impl.NewHashTool(),
+		impl.NewRegexTool(),
+		impl.NewValidateDocumentTool(),
+		impl.NewConvertDocumentTool(),
+		impl.NewQueryDocumentTool(),
+		impl.NewValidateJSONSchemaTool(),
+	}
+}
+
+// filesystemTools 返回限定在配置目录内的文件系统工具，只读模式下不包含写入和移动工具
+func filesystemTools(c FilesystemConfig) []tool.Handler {
+	fsys := sandbox.New(sandbox.Options{
+		Roots:        c.Roots,
+		ReadOnly:     c.ReadOnly,
+		MaxFileBytes: c.MaxFileBytes,
+	})
+	handlers := []tool.Handler{
+		impl.NewReadFileTool(fsys),
+		impl.NewListDirectoryTool(fsys),
+		impl.NewSearchFilesTool(fsys),
+		impl.NewStatTool(fsys),
+	}
+	if !c.ReadOnly {
+		handlers = append(handlers, impl.NewWriteFileTool(fsys), impl.NewMoveTool(fsys))
+	}
+	return handlers
+}
+
+// newToolManager 创建工具管理器并注册所有未被禁用的内置工具，配置了根目录时同时注册文件系统工具
+func newToolManager() *manager.Manager {
+	toolManager := manager.NewToolManager()
+	handlers := builtinTools()
+	if len(cfg.Tools.Filesystem.Roots) > 0 {
+		handlers = append(handlers, filesystemTools(cfg.Tools.Filesystem)...)
+	}
+	for _, handler := range handlers {
+		if slices.Contains(cfg.Tools.Disabled, handler.Name()) {
+			continue
+		}
+		toolManager.RegisterTool(handler)
+	}
+	return toolManager
+}
+
 func runServer() {
 	// 初始化工具管理器
-	toolManager := manager.NewToolManager()
-	toolManager.RegisterTool(impl.NewCalculatorTool())
-	toolManager.RegisterTool(impl.NewStringReverseTool())
+	toolManager := newToolManager()
 
 	// 创建 MCP 服务器
 	s := server.NewMCPServer(
@@ -29,89 +99,167 @@ func runServer() {
 	)
 
 	toolManager.RegisterAllTools(s)
-	log.Infof("Starting MCP server in %s mode on port %s", cfg.Mode, cfg.Port)
+	watchLogLevels()
+	if TransportMode(cfg.Mode) == StdioMode {
+		log.Infof("Starting MCP server in %s mode", cfg.Mode)
+	} else {
+		log.Infof("Starting MCP server in %s mode on %s", cfg.Mode, cfg.serverURL(""))
+	}
 
 	switch TransportMode(cfg.Mode) {
 	case StdioMode:
 		startStdioServer(s)
 	case SSEMode:
-		startSSEServer(s)
+		startSSEServer(s, toolManager)
 	case HTTPMode:
-		startHTTPServer(s)
+		startHTTPServer(s, toolManager)
 	default:
 		log.Fatalf("Unknown mode: %s", cfg.Mode)
 	}
 }
 
+// startStdioServer 以 stdin/stdout 作为 JSON-RPC 通道，日志已在 initConfig 中改为输出到 stderr
 func startStdioServer(s *server.MCPServer) {
-	fmt.Println("Starting MCP server in stdio mode...")
-	if err := server.ServeStdio(s); err != nil {
+	stdout, restore, err := guardStdout()
+	if err != nil {
+		log.Fatalf("Failed to guard stdout: %v", err)
+	}
+	defer restore()
+
+	stdioServer := server.NewStdioServer(s)
+	stdioServer.SetErrorLogger(slog.NewLogLogger(log.NewSlogHandler("mcp"), slog.LevelError))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("Serving JSON-RPC on stdin/stdout")
+	if err := stdioServer.Listen(ctx, os.Stdin, stdout); err != nil && !errors.Is(err, context.Canceled) {
 		log.Fatalf("Stdio server error: %v", err)
 	}
 }
 
-func startSSEServer(s *server.MCPServer) {
+func startSSEServer(s *server.MCPServer, toolManager *manager.Manager) {
 	// 使用 Gin 框架
-	router := gin.New()
-	router.Use(gin.Recovery(), gin.Logger())
+	router := newRouter()
+	group := router.Group(cfg.Routes.BasePath)
 
-	// 创建 SSE 处理器
-	sseHandler := server.NewSSEServer(s)
+	// 创建 SSE 处理器，SSE 连接和客户端消息使用不同的端点
+	sseServer := server.NewSSEServer(s, sseOptions()...)
 
 	// 注册路由
-	router.GET("/sse", gin.WrapH(sseHandler))
-	router.GET("/health", healthCheckHandler)
+	group.GET(cfg.Routes.SSE, gin.WrapH(sseServer.SSEHandler()))
+	group.POST(cfg.Routes.Message, gin.WrapH(sseServer.MessageHandler()))
+	registerCommonRoutes(group, s, toolManager, gin.H{
+		"sse":     cfg.Routes.SSE,
+		"message": cfg.Routes.Message,
+	})
 
 	// 优雅关闭支持
-	srv := &http.Server{
-		Addr:    ":" + cfg.Port,
-		Handler: router,
-	}
+	srv := newServer(router)
 
-	log.Infof("Starting SSE srv on http://localhost:%s/sse", cfg.Port)
+	log.Infof("Starting SSE srv on %s", cfg.serverURL(routePath(cfg.Routes.SSE)))
 
-	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+	if err := listenAndServe(srv); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("SSE srv error: %v", err)
 	}
 }
 
-func startHTTPServer(s *server.MCPServer) {
-	router := gin.New()
-	router.Use(gin.Recovery(), gin.Logger())
+func startHTTPServer(s *server.MCPServer, toolManager *manager.Manager) {
+	router := newRouter()
+	group := router.Group(cfg.Routes.BasePath)
 
 	// 创建 HTTP 处理器
-	httpHandler := server.NewStreamableHTTPServer(s)
+	httpHandler := gin.WrapH(server.NewStreamableHTTPServer(s, server.WithLogger(log.Module("mcp"))))
 
-	// 注册路由
-	router.POST("/mcp", gin.WrapH(httpHandler))
-	router.GET("/mcp", gin.WrapH(httpHandler)) // 支持 GET 请求
-	router.GET("/health", healthCheckHandler)
-	router.GET("/metrics", metricsHandler)
-
-	// API 文档路由
-	router.GET("/", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"service": "MCP Server",
-			"version": "1.0.0",
-			"mode":    cfg.Mode,
-			"endpoints": gin.H{
-				"mcp":     "/mcp",
-				"health":  "/health",
-				"metrics": "/metrics",
-			},
+	// 注册路由，GET 用于服务端推送，DELETE 用于结束会话
+	group.POST(cfg.Routes.MCP, httpHandler)
+	group.GET(cfg.Routes.MCP, httpHandler)
+	group.DELETE(cfg.Routes.MCP, httpHandler)
+	registerCommonRoutes(group, s, toolManager, gin.H{
+		"mcp": cfg.Routes.MCP,
+	})
+
+	srv := newServer(router)
+
+	log.Infof("Starting HTTP srv on %s", cfg.serverURL(routePath(cfg.Routes.MCP)))
+
+	if err := listenAndServe(srv); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("HTTP srv error: %v", err)
+	}
+}
+
+// registerCommonRoutes 注册 HTTP 和 SSE 模式共用的路由，endpoints 为各模式自己的端点
+func registerCommonRoutes(group *gin.RouterGroup, s *server.MCPServer, toolManager *manager.Manager, endpoints gin.H) {
+	group.GET(cfg.Routes.Health, healthCheckHandler)
+	group.GET(cfg.Routes.Metrics, metricsHandler)
+	registerConsole(group, s, toolManager)
+	registerAdmin(group)
+
+	endpoints["health"] = cfg.Routes.Health
+	endpoints["metrics"] = cfg.Routes.Metrics
+	// API 文档路由，返回客户端可以直接访问的完整地址
+	group.GET("/", func(c *gin.Context) {
+		baseURL := externalBaseURL(c.Request)
+		urls := make(gin.H, len(endpoints))
+		for name, path := range endpoints {
+			urls[name] = baseURL + path.(string)
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"service":   "MCP Server",
+			"version":   "1.0.0",
+			"mode":      cfg.Mode,
+			"endpoints": urls,
 		})
 	})
+}
 
-	srv := &http.Server{
-		Addr:    ":" + cfg.Port,
-		Handler: router,
+// newRouter 创建 HTTP 和 SSE 模式共用的 Gin 路由
+func newRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery(), gin.Logger(), originMiddleware)
+	if cfg.Server.MaxBodyBytes > 0 {
+		router.Use(bodyLimitMiddleware(cfg.Server.MaxBodyBytes))
+	}
+	if cfg.TLS.Enabled {
+		router.Use(clientIdentityMiddleware)
 	}
+	return router
+}
 
-	log.Infof("Starting HTTP srv on http://localhost:%s/mcp", cfg.Port)
+// listenAndServe 按配置在 TCP 端口或 Unix socket 上以 HTTP 或 HTTPS 启动服务，
+// HTTPS 的证书在文件变化时自动重新加载
+func listenAndServe(srv *http.Server) error {
+	var reloader *certReloader
+	if cfg.TLS.Enabled {
+		var err error
+		if reloader, err = newCertReloader(cfg.TLS); err != nil {
+			return err
+		}
+		if srv.TLSConfig, err = reloader.tlsConfig(); err != nil {
+			return err
+		}
+	}
 
-	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		log.Fatalf("HTTP srv error: %v", err)
+	l, err := newListener()
+	if err != nil {
+		return err
+	}
+	if reloader == nil {
+		return srv.Serve(l)
 	}
+	if err := reloader.watch(); err != nil {
+		log.Warnf("TLS certificate reload disabled: %v", err)
+	}
+	return srv.ServeTLS(l, "", "")
+}
+
+// registerConsole 按配置注册 Web 控制台
+func registerConsole(router gin.IRouter, s *server.MCPServer, toolManager *manager.Manager) {
+	if !cfg.Console.Enabled {
+		return
+	}
+	console.NewConsole(s, toolManager).Register(router, cfg.Console.Path, tokenAuthMiddleware(cfg.Console.Token))
+	log.Infof("Web console enabled at %s", cfg.Console.Path)
 }
 
 func healthCheckHandler(c *gin.Context) {
@@ -130,56 +278,6 @@ func metricsHandler(c *gin.Context) {
 			"active_connections": 0, // 可以添加实际指标
 			"requests_served":    0,
 		},
+		"log_outputs": log.Stats(),
 	})
 }
-
-func initConfig() {
-	setDefaultValue()
-	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
-	} else {
-		viper.AddConfigPath(".")
-		viper.AddConfigPath("/app")
-		viper.SetConfigType("yaml")
-		viper.SetConfigName("config.yaml")
-	}
-
-	// read in environment variables that match
-	viper.AutomaticEnv()
-	// 读取环境变量的前缀小写,将自动转变为大写。
-	viper.SetEnvPrefix("MCP")
-
-	//将 viper.Get(key) key 字符串中 '.' 和 '-' 替换为 '_'
-	replacer := strings.NewReplacer(".", "_", "-", "_")
-	viper.SetEnvKeyReplacer(replacer)
-
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
-	}
-
-	if err := viper.Unmarshal(&cfg); err != nil {
-		log.Fatalf("Unable to decode config: %v", err)
-	}
-
-	// 设置 Gin 模式
-	gin.SetMode(cfg.GinMode)
-	log.Infoln("Using config file", "file", viper.ConfigFileUsed())
-}
-
-func setDefaultValue() {
-	// 设置默认值
-	viper.SetDefault("mode", "streamableHttp")
-	viper.SetDefault("port", "8081")
-	viper.SetDefault("log_level", "info")
-	viper.SetDefault("gin_mode", "release")
-	//设置日志默认值
-	viper.SetDefault("log.level", "info")
-	viper.SetDefault("log.output", "stdout")
-	viper.SetDefault("log.format", "text")
-	viper.SetDefault("log.enableCaller", true)
-	viper.SetDefault("log.disableStdout", false)
-	viper.SetDefault("log.maxSize", 10)
-	viper.SetDefault("log.maxBackups", 3)
-	viper.SetDefault("log.maxAge", 7)
-	viper.SetDefault("log.timeFormat", "human")
-}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// listen 地址支持的 scheme
+const (
+	listenTCP  = "tcp"
+	listenUnix = "unix"
+)
+
+// parseListenAddress 解析 unix:///run/mcp.sock 或 tcp://127.0.0.1:8081 形式的监听地址
+func parseListenAddress(listen string) (network string, address string, err error) {
+	scheme, rest, ok := strings.Cut(listen, "://")
+	if !ok || rest == "" {
+		return "", "", fmt.Errorf("%q must be in unix:///path or tcp://host:port form", listen)
+	}
+	switch scheme {
+	case listenUnix:
+		return listenUnix, rest, nil
+	case listenTCP:
+		_, port, err := net.SplitHostPort(rest)
+		if err != nil {
+			return "", "", err
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+			return "", "", fmt.Errorf("port %q is out of range 0-65535", port)
+		}
+		return listenTCP, rest, nil
+	default:
+		return "", "", fmt.Errorf("unsupported scheme %q, must be unix or tcp", scheme)
+	}
+}
+
+// listenAddress 返回监听的网络和地址，未配置 listen 时监听所有地址的 port 端口
+func (c *Config) listenAddress() (network string, address string) {
+	if c.Listen == "" {
+		return listenTCP, ":" + c.Port
+	}
+	network, address, _ = parseListenAddress(c.Listen)
+	return network, address
+}
+
+// serverURL 返回用于日志展示的服务地址，Unix socket 使用 http+unix://<转义的路径> 形式
+func (c *Config) serverURL(path string) string {
+	network, address := c.listenAddress()
+	if network == listenUnix {
+		return c.scheme() + "+unix://" + url.PathEscape(address) + path
+	}
+	host, port, _ := net.SplitHostPort(address)
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return c.scheme() + "://" + net.JoinHostPort(host, port) + path
+}
+
+// socketPerm 解析 Unix socket 文件的权限，例如 0660
+func socketPerm(perm string) (fs.FileMode, error) {
+	mode, err := strconv.ParseUint(perm, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("%q is not an octal permission such as 0660", perm)
+	}
+	return fs.FileMode(mode), nil
+}
+
+// validateListen 校验监听地址和 Unix socket 权限
+func validateListen(c *Config) ValidationError {
+	var errs ValidationError
+	if c.Listen == "" {
+		return nil
+	}
+	network, _, err := parseListenAddress(c.Listen)
+	if err != nil {
+		return append(errs, FieldError{Key: "listen", Message: err.Error()})
+	}
+	if network == listenUnix {
+		if _, err := socketPerm(c.SocketPerm); err != nil {
+			errs = append(errs, FieldError{Key: "socket_perm", Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// newListener 按配置创建监听，Unix socket 会清理上次异常退出留下的 socket 文件并设置权限
+func newListener() (net.Listener, error) {
+	network, address := cfg.listenAddress()
+	if network != listenUnix {
+		return net.Listen(network, address)
+	}
+
+	perm, err := socketPerm(cfg.SocketPerm)
+	if err != nil {
+		return nil, err
+	}
+	if err := removeStaleSocket(address); err != nil {
+		return nil, err
+	}
+	l, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(address, perm); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("set socket permissions: %w", err)
+	}
+	return l, nil
+}
+
+// removeStaleSocket 删除无人监听的 socket 文件，仍有进程监听或不是 socket 时返回错误
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if conn, err := net.DialTimeout(listenUnix, path, time.Second); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("%s is already in use", path)
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"mcp-go-tutorials/pkg/log"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+var (
+	// logLevelMu 串行化配置文件监听、SIGHUP 重新加载、SIGUSR1 切换和管理接口对日志级别的修改，
+	// 同时保护 debugToggle
+	logLevelMu sync.Mutex
+
+	// debugToggle 记录 SIGUSR1 切换到 debug 前的日志级别
+	debugToggle struct {
+		enabled  bool
+		previous string
+	}
+)
+
+// watchLogLevels 监听配置文件和信号，在运行时调整日志级别
+func watchLogLevels() {
+	if viper.ConfigFileUsed() != "" {
+		viper.OnConfigChange(func(e fsnotify.Event) {
+			log.Infof("Config file changed: %s", e.Name)
+			logLevelMu.Lock()
+			defer logLevelMu.Unlock()
+			applyLogLevels()
+		})
+		viper.WatchConfig()
+	}
+	handleSignals()
+}
+
+// reloadLogLevels 重新读取配置文件并应用日志级别
+func reloadLogLevels() {
+	logLevelMu.Lock()
+	defer logLevelMu.Unlock()
+
+	if viper.ConfigFileUsed() != "" {
+		if err := viper.ReadInConfig(); err != nil {
+			log.Errorf("Failed to reload config file: %v", err)
+			return
+		}
+	}
+	applyLogLevels()
+}
+
+// applyLogLevels 将配置中的全局和模块日志级别应用到日志，调用方需持有 logLevelMu
+func applyLogLevels() {
+	level := viper.GetString("log.level")
+	if err := log.SetLevel(level); err != nil {
+		log.Errorf("Invalid log.level %q: %v", level, err)
+		return
+	}
+	resetDebugToggle()
+	modules, err := log.ParseModuleLevels(viper.GetStringSlice("log.modules"))
+	if err != nil {
+		log.Errorf("Invalid log.modules: %v", err)
+		return
+	}
+	if err := log.SetModuleLevels(modules); err != nil {
+		log.Errorf("Failed to apply log.modules: %v", err)
+		return
+	}
+	log.Infof("Log level reloaded: level=%s modules=%v", log.GetLevel(), log.ModuleLevels())
+}
+
+// toggleDebugLevel 在 debug 级别和之前的级别之间切换
+func toggleDebugLevel() {
+	logLevelMu.Lock()
+	defer logLevelMu.Unlock()
+
+	level := "debug"
+	if debugToggle.enabled {
+		level = debugToggle.previous
+	} else {
+		debugToggle.previous = log.GetLevel()
+	}
+	if err := log.SetLevel(level); err != nil {
+		log.Errorf("Failed to toggle log level: %v", err)
+		return
+	}
+	debugToggle.enabled = !debugToggle.enabled
+	log.Infof("Log level toggled to %s", level)
+}
+
+// resetDebugToggle 在日志级别被其他途径修改后清除切换状态，
+// 避免下一次 SIGUSR1 恢复到过时的级别，调用方需持有 logLevelMu
+func resetDebugToggle() {
+	debugToggle.enabled = false
+	debugToggle.previous = ""
+}
@@ -0,0 +1,445 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mcp-go-tutorials/internal/pkg/mcpclient"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/chzyer/readline"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+// replCommands REPL 支持的命令及说明
+var replCommands = []struct {
+	name  string
+	usage string
+}{
+	{"help", "help                         show this help"},
+	{"info", "info                         show the server's initialize result"},
+	{"tools", "tools                        list tools"},
+	{"describe", "describe <tool>              show a tool's input schema"},
+	{"call", "call <tool> [k=v ...|{json}] call a tool"},
+	{"resources", "resources                    list resources"},
+	{"read", "read <uri>                   read a resource"},
+	{"prompts", "prompts                      list prompts"},
+	{"prompt", "prompt <name> [k=v ...]      get a prompt"},
+	{"loglevel", "loglevel <level>             set the server's logging level"},
+	{"ping", "ping                         ping the server"},
+	{"exit", "exit                         leave the REPL (also quit, Ctrl-D)"},
+}
+
+// newReplCmd 创建 repl 子命令，提供交互式的 MCP 客户端
+func newReplCmd() *cobra.Command {
+	flags := &clientFlags{}
+	var historyFile string
+	cmd := &cobra.Command{
+		Use:   "repl",
+		Short: "Interactive MCP client shell with completion and history",
+		Example: `  mcp-server repl --url http://localhost:8081/mcp
+  mcp-server repl --command "./mcp-server --mode stdio"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := flags.connect(context.Background())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = c.Close() }()
+
+			r, err := newRepl(c, historyFile)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = r.rl.Close() }()
+			return r.run()
+		},
+	}
+	flags.addFlags(cmd)
+	cmd.Flags().StringVar(&historyFile, "history", defaultHistoryFile(), "History file, empty to disable")
+	return cmd
+}
+
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".mcp_server_history")
+}
+
+// repl 交互式会话
+type repl struct {
+	client *mcpclient.Client
+	rl     *readline.Instance
+
+	refreshMu sync.Mutex // 串行化刷新，避免较早的结果覆盖较新的结果
+	mu        sync.RWMutex
+	tools     []mcp.Tool
+	resources []string
+	prompts   []string
+
+	progressToken atomic.Int64
+}
+
+func newRepl(c *mcpclient.Client, historyFile string) (*repl, error) {
+	r := &repl{client: c}
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "mcp> ",
+		HistoryFile:     historyFile,
+		AutoComplete:    r,
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.rl = rl
+
+	c.OnNotification(r.handleNotification)
+	r.refreshCompletions()
+	return r, nil
+}
+
+func (r *repl) run() error {
+	info := r.client.ServerInfo
+	r.printf("Connected to %s %s (protocol %s). Type \"help\" for commands.\n",
+		info.ServerInfo.Name, info.ServerInfo.Version, info.ProtocolVersion)
+
+	for {
+		line, err := r.rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		words, err := mcpclient.SplitCommand(line)
+		if err != nil {
+			r.printf("error: %v\n", err)
+			continue
+		}
+		if len(words) == 0 {
+			continue
+		}
+		if words[0] == "exit" || words[0] == "quit" {
+			return nil
+		}
+		if err := r.execute(words[0], words[1:], line); err != nil {
+			r.printf("error: %v\n", err)
+		}
+	}
+}
+
+// execute 执行单条命令
+func (r *repl) execute(command string, args []string, line string) error {
+	ctx, cancel := r.client.RequestContext(context.Background())
+	defer cancel()
+
+	switch command {
+	case "help":
+		for _, c := range replCommands {
+			r.printf("  %s\n", c.usage)
+		}
+		return nil
+	case "info":
+		return r.printValue(r.client.ServerInfo)
+	case "ping":
+		if err := r.client.Ping(ctx); err != nil {
+			return err
+		}
+		r.printf("pong\n")
+		return nil
+	case "tools":
+		r.refreshCompletions()
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for _, t := range r.tools {
+			r.printf("  %-20s %s\n", t.Name, t.Description)
+		}
+		return nil
+	case "describe":
+		if len(args) != 1 {
+			return errors.New("usage: describe <tool>")
+		}
+		t, ok := r.findTool(args[0])
+		if !ok {
+			return fmt.Errorf("tool %q not found", args[0])
+		}
+		return r.printValue(t)
+	case "call":
+		return r.callTool(ctx, args, line)
+	case "resources":
+		result, err := r.client.ListResources(ctx, mcp.ListResourcesRequest{})
+		if err != nil {
+			return err
+		}
+		for _, res := range result.Resources {
+			r.printf("  %-30s %s\n", res.URI, res.Name)
+		}
+		return nil
+	case "read":
+		if len(args) != 1 {
+			return errors.New("usage: read <uri>")
+		}
+		request := mcp.ReadResourceRequest{}
+		request.Params.URI = args[0]
+		result, err := r.client.ReadResource(ctx, request)
+		if err != nil {
+			return err
+		}
+		for _, content := range result.Contents {
+			if text, ok := mcp.AsTextResourceContents(content); ok {
+				r.printf("%s\n", text.Text)
+				continue
+			}
+			if err := r.printValue(content); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "prompts":
+		result, err := r.client.ListPrompts(ctx, mcp.ListPromptsRequest{})
+		if err != nil {
+			return err
+		}
+		for _, p := range result.Prompts {
+			r.printf("  %-20s %s\n", p.Name, p.Description)
+		}
+		return nil
+	case "prompt":
+		if len(args) == 0 {
+			return errors.New("usage: prompt <name> [k=v ...]")
+		}
+		arguments, err := parseKeyValues(args[1:])
+		if err != nil {
+			return err
+		}
+		request := mcp.GetPromptRequest{}
+		request.Params.Name = args[0]
+		request.Params.Arguments = arguments
+		result, err := r.client.GetPrompt(ctx, request)
+		if err != nil {
+			return err
+		}
+		return r.printValue(result)
+	case "loglevel":
+		if len(args) != 1 {
+			return errors.New("usage: loglevel <level>")
+		}
+		request := mcp.SetLevelRequest{}
+		request.Params.Level = mcp.LoggingLevel(args[0])
+		return r.client.SetLevel(ctx, request)
+	default:
+		return fmt.Errorf("unknown command %q, type \"help\" for commands", command)
+	}
+}
+
+// callTool 调用工具，参数可以是 k=v 列表或单个 JSON 对象
+func (r *repl) callTool(ctx context.Context, args []string, line string) error {
+	if len(args) == 0 {
+		return errors.New("usage: call <tool> [k=v ...|{json}]")
+	}
+	t, ok := r.findTool(args[0])
+	if !ok {
+		return fmt.Errorf("tool %q not found", args[0])
+	}
+
+	// JSON 参数直接取原始输入，避免引号被 SplitCommand 去掉
+	var jsonArgs string
+	rawArgs := args[1:]
+	if len(rawArgs) > 0 && strings.HasPrefix(rawArgs[0], "{") {
+		jsonArgs, rawArgs = line[strings.Index(line, "{"):], nil
+	}
+	arguments, err := parseToolArguments(t, jsonArgs, rawArgs)
+	if err != nil {
+		return err
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = t.Name
+	request.Params.Arguments = arguments
+	request.Params.Meta = &mcp.Meta{ProgressToken: r.progressToken.Add(1)}
+	result, err := r.client.CallTool(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if result.IsError {
+		r.printf("tool error:\n")
+	}
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			r.printf("%s\n", text.Text)
+			continue
+		}
+		if err := r.printValue(content); err != nil {
+			return err
+		}
+	}
+	if result.StructuredContent != nil {
+		return r.printValue(result.StructuredContent)
+	}
+	return nil
+}
+
+// handleNotification 实时显示服务端推送的通知
+func (r *repl) handleNotification(n mcp.JSONRPCNotification) {
+	fields := n.Params.AdditionalFields
+	switch n.Method {
+	case string(mcp.MethodNotificationToolsListChanged):
+		r.printf("[notification] tool list changed\n")
+		// 通知回调运行在传输层的读循环上，同步请求会等不到响应
+		go r.refreshCompletions()
+	case "notifications/progress":
+		r.printf("[progress %v] %v/%v %v\n", fields["progressToken"], fields["progress"], valueOr(fields["total"], "?"), valueOr(fields["message"], ""))
+	case "notifications/message":
+		data, _ := json.Marshal(fields["data"])
+		r.printf("[log %v] %v %s\n", fields["level"], valueOr(fields["logger"], "-"), data)
+	default:
+		data, _ := json.Marshal(fields)
+		r.printf("[notification] %s %s\n", n.Method, data)
+	}
+}
+
+// refreshCompletions 刷新用于补全的工具、资源和提示词列表，请求失败时保留原来的列表
+func (r *repl) refreshCompletions() {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	ctx, cancel := r.client.RequestContext(context.Background())
+	defer cancel()
+
+	capabilities := r.client.ServerInfo.Capabilities
+	if capabilities.Tools != nil {
+		if result, err := r.client.ListTools(ctx, mcp.ListToolsRequest{}); err == nil {
+			r.mu.Lock()
+			r.tools = result.Tools
+			r.mu.Unlock()
+		}
+	}
+	if capabilities.Resources != nil {
+		if result, err := r.client.ListResources(ctx, mcp.ListResourcesRequest{}); err == nil {
+			resources := make([]string, 0, len(result.Resources))
+			for _, res := range result.Resources {
+				resources = append(resources, res.URI)
+			}
+			r.mu.Lock()
+			r.resources = resources
+			r.mu.Unlock()
+		}
+	}
+	if capabilities.Prompts != nil {
+		if result, err := r.client.ListPrompts(ctx, mcp.ListPromptsRequest{}); err == nil {
+			prompts := make([]string, 0, len(result.Prompts))
+			for _, p := range result.Prompts {
+				prompts = append(prompts, p.Name)
+			}
+			r.mu.Lock()
+			r.prompts = prompts
+			r.mu.Unlock()
+		}
+	}
+}
+
+func (r *repl) findTool(name string) (mcp.Tool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return mcp.Tool{}, false
+}
+
+// Do 实现 readline.AutoCompleter，补全命令、工具名、参数名、资源和提示词
+func (r *repl) Do(line []rune, pos int) ([][]rune, int) {
+	text := string(line[:pos])
+	words := strings.Fields(text)
+	// 光标前的最后一个单词尚未输入完成，作为补全前缀
+	partial := ""
+	if len(words) > 0 && !strings.HasSuffix(text, " ") {
+		partial = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var candidates []string
+	switch {
+	case len(words) == 0:
+		for _, c := range replCommands {
+			candidates = append(candidates, c.name+" ")
+		}
+	case len(words) == 1 && (words[0] == "call" || words[0] == "describe"):
+		r.mu.RLock()
+		for _, t := range r.tools {
+			candidates = append(candidates, t.Name+" ")
+		}
+		r.mu.RUnlock()
+	case len(words) >= 2 && words[0] == "call":
+		if t, ok := r.findTool(words[1]); ok {
+			candidates = argumentCandidates(t, words[2:])
+		}
+	case len(words) == 1 && words[0] == "read":
+		r.mu.RLock()
+		candidates = append(candidates, r.resources...)
+		r.mu.RUnlock()
+	case len(words) == 1 && words[0] == "prompt":
+		r.mu.RLock()
+		for _, p := range r.prompts {
+			candidates = append(candidates, p+" ")
+		}
+		r.mu.RUnlock()
+	}
+
+	var result [][]rune
+	for _, c := range candidates {
+		if strings.HasPrefix(c, partial) {
+			result = append(result, []rune(c[len(partial):]))
+		}
+	}
+	return result, len([]rune(partial))
+}
+
+// argumentCandidates 返回尚未填写的参数名，形如 "name="
+func argumentCandidates(t mcp.Tool, given []string) []string {
+	used := make(map[string]bool, len(given))
+	for _, g := range given {
+		key, _, _ := strings.Cut(g, "=")
+		used[key] = true
+	}
+	var names []string
+	for name := range t.InputSchema.Properties {
+		if !used[name] {
+			names = append(names, name+"=")
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *repl) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(r.rl.Stdout(), format, args...)
+}
+
+func (r *repl) printValue(v any) error {
+	return printJSON(r.rl.Stdout(), v)
+}
+
+func valueOr(v any, fallback any) any {
+	if v == nil {
+		return fallback
+	}
+	return v
+}
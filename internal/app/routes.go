@@ -0,0 +1,116 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RoutesConfig HTTP 和 SSE 模式的路由配置
+type RoutesConfig struct {
+	BasePath string `mapstructure:"base_path"` // 所有路由的前缀，例如 /mcp-server
+	// BaseURL 对外的访问地址，例如 https://mcp.example.com，配置后 SSE 的消息端点使用完整 URL
+	BaseURL string `mapstructure:"base_url"`
+	// TrustForwardedHeaders 是否信任反向代理设置的 X-Forwarded-Proto、X-Forwarded-Host 和 X-Forwarded-Prefix
+	TrustForwardedHeaders bool   `mapstructure:"trust_forwarded_headers"`
+	MCP                   string `mapstructure:"mcp"`
+	SSE                   string `mapstructure:"sse"`
+	Message               string `mapstructure:"message"` // SSE 模式接收客户端消息的端点
+	Health                string `mapstructure:"health"`
+	Metrics               string `mapstructure:"metrics"`
+}
+
+// validateRoutes 校验路由配置
+func validateRoutes(c RoutesConfig) ValidationError {
+	var errs ValidationError
+	add := func(key string, format string, args ...any) {
+		errs = append(errs, FieldError{Key: "routes." + key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if c.BasePath != "" && (!strings.HasPrefix(c.BasePath, "/") || strings.HasSuffix(c.BasePath, "/")) {
+		add("base_path", "%q must start with / and must not end with /", c.BasePath)
+	}
+	if c.BaseURL != "" {
+		if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("base_url", "%q must be an http or https URL", c.BaseURL)
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, route := range []struct{ key, path string }{
+		{"mcp", c.MCP}, {"sse", c.SSE}, {"message", c.Message}, {"health", c.Health}, {"metrics", c.Metrics},
+	} {
+		if !strings.HasPrefix(route.path, "/") {
+			add(route.key, "%q must start with /", route.path)
+			continue
+		}
+		if other, ok := seen[route.path]; ok {
+			add(route.key, "%q is already used by routes.%s", route.path, other)
+			continue
+		}
+		seen[route.path] = route.key
+	}
+	slices.SortFunc(errs, func(a, b FieldError) int { return strings.Compare(a.Key, b.Key) })
+	return errs
+}
+
+// routePath 返回加上 base_path 前缀的路由
+func routePath(path string) string {
+	return cfg.Routes.BasePath + path
+}
+
+// forwardedHeader 在信任反向代理时返回 X-Forwarded-* 头的第一个值
+func forwardedHeader(r *http.Request, name string) string {
+	if !cfg.Routes.TrustForwardedHeaders {
+		return ""
+	}
+	value, _, _ := strings.Cut(r.Header.Get(name), ",")
+	return strings.TrimSpace(value)
+}
+
+// externalBasePath 返回客户端看到的路由前缀，反向代理去掉的前缀通过 X-Forwarded-Prefix 传入
+func externalBasePath(r *http.Request) string {
+	if cfg.Routes.BaseURL != "" {
+		return cfg.Routes.BasePath
+	}
+	return strings.TrimSuffix(forwardedHeader(r, "X-Forwarded-Prefix"), "/") + cfg.Routes.BasePath
+}
+
+// externalBaseURL 返回客户端访问服务的地址，优先使用 base_url，其次是 X-Forwarded-* 头和请求本身
+func externalBaseURL(r *http.Request) string {
+	if cfg.Routes.BaseURL != "" {
+		return strings.TrimSuffix(cfg.Routes.BaseURL, "/") + cfg.Routes.BasePath
+	}
+	scheme := forwardedHeader(r, "X-Forwarded-Proto")
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+	host := forwardedHeader(r, "X-Forwarded-Host")
+	if host == "" {
+		host = r.Host
+	}
+	return scheme + "://" + host + externalBasePath(r)
+}
+
+// sseOptions 返回 SSE 服务的选项，消息端点按请求计算以支持反向代理的路由前缀
+func sseOptions() []server.SSEOption {
+	options := []server.SSEOption{
+		server.WithSSEEndpoint(cfg.Routes.SSE),
+		server.WithMessageEndpoint(cfg.Routes.Message),
+		server.WithDynamicBasePath(func(r *http.Request, _ string) string {
+			return externalBasePath(r)
+		}),
+	}
+	// 未配置 base_url 时消息端点是相对路径，客户端会基于连接 SSE 时使用的地址解析
+	if cfg.Routes.BaseURL != "" {
+		options = append(options, server.WithBaseURL(strings.TrimSuffix(cfg.Routes.BaseURL, "/")))
+	}
+	return options
+}
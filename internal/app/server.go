@@ -0,0 +1,166 @@
+package app
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"mcp-go-tutorials/pkg/log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServerConfig HTTP 和 SSE 服务的超时、大小限制和跨域配置
+type ServerConfig struct {
+	ReadTimeout       time.Duration `mapstructure:"read_timeout"`
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+	WriteTimeout      time.Duration `mapstructure:"write_timeout"` // SSE 和 streamableHttp 的 GET 是长连接，非 0 时会被强制断开
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+	MaxBodyBytes      int64         `mapstructure:"max_body_bytes"` // 0 表示不限制
+	MaxHeaderBytes    int           `mapstructure:"max_header_bytes"`
+	// CheckOrigin 拒绝 Origin 不在 cors.allowed_origins 中且不是本机地址的请求，防止 DNS 重绑定攻击
+	CheckOrigin bool       `mapstructure:"check_origin"`
+	CORS        CORSConfig `mapstructure:"cors"`
+}
+
+// CORSConfig 跨域访问配置
+type CORSConfig struct {
+	AllowedOrigins []string      `mapstructure:"allowed_origins"` // 允许的 Origin，例如 https://app.example.com，* 表示所有
+	AllowedHeaders []string      `mapstructure:"allowed_headers"`
+	ExposedHeaders []string      `mapstructure:"exposed_headers"`
+	MaxAge         time.Duration `mapstructure:"max_age"` // 预检请求的缓存时间
+}
+
+// validateServer 校验服务配置
+func validateServer(c ServerConfig) ValidationError {
+	var errs ValidationError
+	add := func(key string, format string, args ...any) {
+		errs = append(errs, FieldError{Key: "server." + key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for key, value := range map[string]time.Duration{
+		"read_timeout":        c.ReadTimeout,
+		"read_header_timeout": c.ReadHeaderTimeout,
+		"write_timeout":       c.WriteTimeout,
+		"idle_timeout":        c.IdleTimeout,
+		"cors.max_age":        c.CORS.MaxAge,
+	} {
+		if value < 0 {
+			add(key, "%s must not be negative", value)
+		}
+	}
+	if c.MaxBodyBytes < 0 {
+		add("max_body_bytes", "%d must not be negative", c.MaxBodyBytes)
+	}
+	if c.MaxHeaderBytes < 0 {
+		add("max_header_bytes", "%d must not be negative", c.MaxHeaderBytes)
+	}
+	for i, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			add(fmt.Sprintf("cors.allowed_origins[%d]", i), "%q must be * or scheme://host[:port]", origin)
+		}
+	}
+	slices.SortFunc(errs, func(a, b FieldError) int { return strings.Compare(a.Key, b.Key) })
+	return errs
+}
+
+// newServer 按配置创建 HTTP 服务
+func newServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+}
+
+// bodyLimitMiddleware 限制请求体大小，超出时返回 413
+func bodyLimitMiddleware(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": fmt.Sprintf("request body exceeds %d bytes", limit),
+			})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// tokenAuthMiddleware 要求请求携带 Authorization: Bearer <token>，否则返回 401
+func tokenAuthMiddleware(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+	return func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid bearer token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// originMiddleware 校验 Origin 并处理跨域请求
+// 没有 Origin 的请求来自非浏览器客户端，不做限制；本机地址的 Origin 总是允许，便于本地调试和 Web 控制台
+func originMiddleware(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	if origin == "" {
+		c.Next()
+		return
+	}
+
+	cors := cfg.Server.CORS
+	allowed := slices.Contains(cors.AllowedOrigins, "*") || slices.Contains(cors.AllowedOrigins, origin)
+	if cfg.Server.CheckOrigin && !allowed && !isLoopbackOrigin(origin) {
+		log.Warnf("Rejected request to %s from origin %s", c.Request.URL.Path, origin)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "origin not allowed"})
+		return
+	}
+
+	if allowed {
+		header := c.Writer.Header()
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Add("Vary", "Origin")
+		if len(cors.ExposedHeaders) > 0 {
+			header.Set("Access-Control-Expose-Headers", strings.Join(cors.ExposedHeaders, ", "))
+		}
+		// 预检请求
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			header.Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			if len(cors.AllowedHeaders) > 0 {
+				header.Set("Access-Control-Allow-Headers", strings.Join(cors.AllowedHeaders, ", "))
+			}
+			if cors.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", strconv.Itoa(int(cors.MaxAge.Seconds())))
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+	}
+	c.Next()
+}
+
+// isLoopbackOrigin 判断 Origin 是否指向本机
+func isLoopbackOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package app
+
+import (
+	"mcp-go-tutorials/pkg/log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// handleSignals SIGHUP 重新加载配置文件中的日志级别，SIGUSR1 切换 debug 日志
+func handleSignals() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGUSR1)
+	go func() {
+		for sig := range signals {
+			log.Infof("Received signal %s", sig)
+			switch sig {
+			case syscall.SIGHUP:
+				reloadLogLevels()
+			case syscall.SIGUSR1:
+				toggleDebugLevel()
+			}
+		}
+	}()
+}
@@ -0,0 +1,6 @@
+//go:build windows
+
+package app
+
+// handleSignals Windows 不支持 SIGHUP/SIGUSR1，仅通过配置文件和管理接口调整日志级别
+func handleSignals() {}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"bufio"
+	"io"
+	"mcp-go-tutorials/pkg/log"
+	"os"
+)
+
+// guardStdout 将 os.Stdout 替换为管道，返回原始 stdout 作为 stdio 模式的 JSON-RPC 通道
+// 之后经由 os.Stdout 的写入不会进入协议流，而是丢弃并记录警告，restore 恢复原始 stdout
+func guardStdout() (stdout *os.File, restore func(), err error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	stdout = os.Stdout
+	os.Stdout = w
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			log.Warnf("Dropped stray write to stdout in stdio mode: %q", scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			log.Warnf("Dropped stray write to stdout in stdio mode: %v", err)
+			// 继续读取，避免写入方阻塞
+			_, _ = io.Copy(io.Discard, r)
+		}
+	}()
+
+	restore = func() {
+		os.Stdout = stdout
+		_ = w.Close()
+		<-done
+		_ = r.Close()
+	}
+	return stdout, restore, nil
+}
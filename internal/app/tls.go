@@ -0,0 +1,229 @@
+package app
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"mcp-go-tutorials/pkg/log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gin-gonic/gin"
+)
+
+// 客户端证书校验方式
+const (
+	ClientAuthNone    = "none"    // 不请求客户端证书
+	ClientAuthRequest = "request" // 客户端提供证书时校验
+	ClientAuthRequire = "require" // 必须提供并通过校验
+)
+
+var (
+	clientAuthModes = []string{ClientAuthNone, ClientAuthRequest, ClientAuthRequire}
+	tlsVersions     = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+)
+
+// TLSConfig HTTP 和 SSE 监听的 TLS 配置
+type TLSConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	CertFile     string   `mapstructure:"cert_file"`
+	KeyFile      string   `mapstructure:"key_file"`
+	MinVersion   string   `mapstructure:"min_version"`   // 1.0, 1.1, 1.2, 1.3
+	CipherSuites []string `mapstructure:"cipher_suites"` // 为空时使用 Go 的默认值，TLS 1.3 不可配置
+	ClientCAFile string   `mapstructure:"client_ca_file"`
+	ClientAuth   string   `mapstructure:"client_auth"` // none, request, require
+}
+
+// validateTLS 校验 TLS 配置，证书文件只检查是否可读，内容在启动时加载
+func validateTLS(c TLSConfig) ValidationError {
+	if !c.Enabled {
+		return nil
+	}
+	var errs ValidationError
+	add := func(key string, format string, args ...any) {
+		errs = append(errs, FieldError{Key: "tls." + key, Message: fmt.Sprintf(format, args...)})
+	}
+	readable := func(key string, file string, when string) {
+		if file == "" {
+			add(key, "must be set when %s", when)
+		} else if _, err := os.Stat(file); err != nil {
+			add(key, "%v", err)
+		}
+	}
+
+	readable("cert_file", c.CertFile, "tls is enabled")
+	readable("key_file", c.KeyFile, "tls is enabled")
+	if _, ok := tlsVersions[c.MinVersion]; !ok {
+		add("min_version", "unknown value %q, must be one of 1.0, 1.1, 1.2, 1.3", c.MinVersion)
+	}
+	if _, err := cipherSuiteIDs(c.CipherSuites); err != nil {
+		add("cipher_suites", "%v", err)
+	}
+	if !slices.Contains(clientAuthModes, c.ClientAuth) {
+		add("client_auth", "unknown value %q, must be one of %s", c.ClientAuth, strings.Join(clientAuthModes, ", "))
+	} else if c.ClientAuth != ClientAuthNone {
+		readable("client_ca_file", c.ClientCAFile, "client_auth is "+c.ClientAuth)
+	}
+	return errs
+}
+
+// cipherSuiteIDs 将密码套件名称转换为 ID，只允许 Go 认为安全的套件
+func cipherSuiteIDs(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// certReloader 持有当前的证书和客户端 CA，文件变化时自动重新加载
+type certReloader struct {
+	config    TLSConfig
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs atomic.Pointer[x509.CertPool]
+}
+
+func newCertReloader(c TLSConfig) (*certReloader, error) {
+	r := &certReloader{config: c}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload 重新加载证书和客户端 CA，失败时保留之前的证书
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load certificate: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.config.ClientAuth != ClientAuthNone {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("load client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("load client CA: no certificates found in " + r.config.ClientCAFile)
+		}
+	}
+	r.cert.Store(&cert)
+	r.clientCAs.Store(pool)
+	return nil
+}
+
+// tlsConfig 创建服务端 TLS 配置，每次握手使用最新加载的证书和客户端 CA
+func (r *certReloader) tlsConfig() (*tls.Config, error) {
+	suites, err := cipherSuiteIDs(r.config.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	base := &tls.Config{
+		MinVersion:   tlsVersions[r.config.MinVersion],
+		CipherSuites: suites,
+	}
+	switch r.config.ClientAuth {
+	case ClientAuthRequest:
+		base.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		base.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	config := base.Clone()
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		c := base.Clone()
+		c.Certificates = []tls.Certificate{*r.cert.Load()}
+		c.ClientCAs = r.clientCAs.Load()
+		return c, nil
+	}
+	return config, nil
+}
+
+// watch 监听证书所在目录，兼容 Kubernetes 等通过替换符号链接更新证书的方式
+func (r *certReloader) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientAuth != ClientAuthNone {
+		files = append(files, r.config.ClientCAFile)
+	}
+	dirs := make(map[string]bool)
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if dirs[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return err
+		}
+		dirs[dir] = true
+	}
+
+	go func() {
+		// 证书和私钥通常先后写入，合并短时间内的多次变化
+		var timer <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Chmod) {
+					continue
+				}
+				timer = time.After(500 * time.Millisecond)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Warnf("Certificate watcher error: %v", err)
+			case <-timer:
+				timer = nil
+				if err := r.reload(); err != nil {
+					log.Errorf("Failed to reload TLS certificate, keeping the previous one: %v", err)
+					continue
+				}
+				log.Infof("TLS certificate reloaded from %s", r.config.CertFile)
+			}
+		}
+	}()
+	return nil
+}
+
+// clientIdentityMiddleware 将 TLS 客户端证书中的身份保存到请求的 context，工具处理器可以通过
+// tool.ClientIdentityFromContext 获取
+func clientIdentityMiddleware(c *gin.Context) {
+	state := c.Request.TLS
+	if state != nil && len(state.PeerCertificates) > 0 {
+		id := tool.NewClientIdentity(state.PeerCertificates[0], len(state.VerifiedChains) > 0)
+		c.Request = c.Request.WithContext(tool.WithClientIdentity(c.Request.Context(), id))
+	}
+	c.Next()
+}
@@ -0,0 +1,238 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+// 输出格式
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// newToolsCmd 创建 tools 子命令，用于在本地列出和调用工具
+func newToolsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "List and invoke tools locally without starting any transport",
+	}
+	cmd.AddCommand(newToolsListCmd(), newToolsCallCmd())
+	return cmd
+}
+
+func newToolsListCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print name, description and input schema of all tools",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+			tools := make([]mcp.Tool, 0)
+			for _, handler := range newToolManager().GetTools() {
+				tools = append(tools, handler.Schema())
+			}
+			return printTools(cmd.OutOrStdout(), tools, output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "Output format: table, json")
+	return cmd
+}
+
+func newToolsCallCmd() *cobra.Command {
+	var (
+		output   string
+		rawArgs  []string
+		jsonArgs string
+	)
+	cmd := &cobra.Command{
+		Use:   "call <name>",
+		Short: "Execute a tool in-process and print its result",
+		Example: `  mcp-server tools call calculate --arg operation=add --arg x=1 --arg y=2
+  mcp-server tools call reverse_string --json '{"text":"hello"}' -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+			handler, ok := newToolManager().GetTool(args[0])
+			if !ok {
+				return fmt.Errorf("tool %q not found", args[0])
+			}
+
+			arguments, err := parseToolArguments(handler.Schema(), jsonArgs, rawArgs)
+			if err != nil {
+				return err
+			}
+
+			request := mcp.CallToolRequest{}
+			request.Params.Name = handler.Name()
+			request.Params.Arguments = arguments
+			result, err := handler.Handle(context.Background(), request)
+			if err != nil {
+				return fmt.Errorf("call tool %s: %w", handler.Name(), err)
+			}
+
+			if err := printToolResult(cmd.OutOrStdout(), result, output); err != nil {
+				return err
+			}
+			if result.IsError {
+				return fmt.Errorf("tool %s returned an error", handler.Name())
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", outputTable, "Output format: table, json")
+	cmd.Flags().StringArrayVar(&rawArgs, "arg", nil, "Tool argument in key=value form, may be repeated")
+	cmd.Flags().StringVar(&jsonArgs, "json", "", "Tool arguments as a JSON object, merged before --arg values")
+	return cmd
+}
+
+// validateOutput 在执行命令前检查输出格式，避免工具已经执行后才报错
+func validateOutput(output string) error {
+	switch output {
+	case outputTable, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format: %s", output)
+	}
+}
+
+// parseToolArguments 解析 --json 和 --arg 参数，按工具 schema 中声明的类型转换取值
+func parseToolArguments(schema mcp.Tool, jsonArgs string, rawArgs []string) (map[string]any, error) {
+	arguments := make(map[string]any)
+	if jsonArgs != "" {
+		if err := json.Unmarshal([]byte(jsonArgs), &arguments); err != nil {
+			return nil, fmt.Errorf("invalid --json arguments: %w", err)
+		}
+	}
+
+	for _, raw := range rawArgs {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --arg %q, expected key=value", raw)
+		}
+		typ := ""
+		if prop, ok := schema.InputSchema.Properties[key].(map[string]any); ok {
+			typ, _ = prop["type"].(string)
+		}
+		converted, err := convertArgument(typ, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for argument %s: %w", key, err)
+		}
+		arguments[key] = converted
+	}
+	return arguments, nil
+}
+
+// convertArgument 将字符串形式的参数转换为 schema 类型对应的值
+func convertArgument(typ string, value string) (any, error) {
+	switch typ {
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "array", "object":
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+func printTools(w io.Writer, tools []mcp.Tool, output string) error {
+	switch output {
+	case outputJSON:
+		return printJSON(w, tools)
+	case outputTable:
+		table := uitable.New()
+		table.MaxColWidth = 80
+		table.AddRow("NAME", "DESCRIPTION", "PARAMETERS")
+		for _, t := range tools {
+			table.AddRow(t.Name, t.Description, describeParameters(t.InputSchema))
+		}
+		_, err := fmt.Fprintln(w, table)
+		return err
+	default:
+		return fmt.Errorf("unknown output format: %s", output)
+	}
+}
+
+// describeParameters 将输入 schema 概括为 "name*:type" 形式，* 表示必填
+func describeParameters(schema mcp.ToolInputSchema) string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]string, 0, len(names))
+	for _, name := range names {
+		typ := "any"
+		if prop, ok := schema.Properties[name].(map[string]any); ok {
+			switch t := prop["type"].(type) {
+			case string:
+				typ = t
+			case []string:
+				typ = strings.Join(t, "|")
+			case []any:
+				types := make([]string, 0, len(t))
+				for _, v := range t {
+					types = append(types, fmt.Sprint(v))
+				}
+				typ = strings.Join(types, "|")
+			}
+		}
+		if slices.Contains(schema.Required, name) {
+			name += "*"
+		}
+		params = append(params, name+":"+typ)
+	}
+	return strings.Join(params, ", ")
+}
+
+func printToolResult(w io.Writer, result *mcp.CallToolResult, output string) error {
+	switch output {
+	case outputJSON:
+		return printJSON(w, result)
+	case outputTable:
+		for _, content := range result.Content {
+			if text, ok := mcp.AsTextContent(content); ok {
+				if _, err := fmt.Fprintln(w, text.Text); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := printJSON(w, content); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format: %s", output)
+	}
+}
+
+func printJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,151 @@
+// Package codec 文本编码和摘要计算，输入输出都是字节，调用方负责大小限制
+package codec
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// 支持的编码
+const (
+	Base64    = "base64"    // 标准 base64（RFC 4648 §4），带填充
+	Base64URL = "base64url" // URL 安全的 base64（RFC 4648 §5），不带填充
+	Base32    = "base32"    // 标准 base32（RFC 4648 §6），带填充
+	Hex       = "hex"       // 小写十六进制
+	URL       = "url"       // 查询参数编码，空格编码为 +
+	Percent   = "percent"   // RFC 3986 百分号编码，只保留非保留字符
+)
+
+// Encodings 支持的编码名称
+var Encodings = []string{Base64, Base64URL, Base32, Hex, URL, Percent}
+
+// Encode 按指定编码编码数据
+func Encode(encoding string, data []byte) (string, error) {
+	switch encoding {
+	case Base64:
+		return base64.StdEncoding.EncodeToString(data), nil
+	case Base64URL:
+		return base64.RawURLEncoding.EncodeToString(data), nil
+	case Base32:
+		return base32.StdEncoding.EncodeToString(data), nil
+	case Hex:
+		return hex.EncodeToString(data), nil
+	case URL:
+		return url.QueryEscape(string(data)), nil
+	case Percent:
+		return percentEncode(data), nil
+	default:
+		return "", unknownEncoding(encoding)
+	}
+}
+
+// Decode 按指定编码解码文本
+// base64、base32 和 hex 忽略空白，填充可以省略；base64 同时接受标准和 URL 安全字母表
+func Decode(encoding string, s string) ([]byte, error) {
+	switch encoding {
+	case Base64, Base64URL:
+		return decodeBase64(stripSpace(s))
+	case Base32:
+		value := strings.ToUpper(stripSpace(s))
+		enc := base32.StdEncoding
+		if !strings.HasSuffix(value, "=") {
+			enc = enc.WithPadding(base32.NoPadding)
+		}
+		data, err := enc.DecodeString(value)
+		return data, describeError(err)
+	case Hex:
+		value := strings.TrimPrefix(strings.TrimPrefix(stripSpace(s), "0x"), "0X")
+		data, err := hex.DecodeString(value)
+		return data, describeError(err)
+	case URL:
+		value, err := url.QueryUnescape(s)
+		return []byte(value), describeError(err)
+	case Percent:
+		value, err := url.PathUnescape(s)
+		return []byte(value), describeError(err)
+	default:
+		return nil, unknownEncoding(encoding)
+	}
+}
+
+// decodeBase64 根据字母表和是否有填充选择 base64 变体
+func decodeBase64(value string) ([]byte, error) {
+	urlSafe := strings.ContainsAny(value, "-_")
+	padded := strings.HasSuffix(value, "=")
+	var enc *base64.Encoding
+	switch {
+	case urlSafe && padded:
+		enc = base64.URLEncoding
+	case urlSafe:
+		enc = base64.RawURLEncoding
+	case padded:
+		enc = base64.StdEncoding
+	default:
+		enc = base64.RawStdEncoding
+	}
+	data, err := enc.DecodeString(value)
+	return data, describeError(err)
+}
+
+// percentEncode 编码 RFC 3986 非保留字符（字母、数字和 -._~）之外的所有字节
+func percentEncode(data []byte) string {
+	const upperHex = "0123456789ABCDEF"
+	var b strings.Builder
+	b.Grow(len(data))
+	for _, c := range data {
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperHex[c>>4])
+		b.WriteByte(upperHex[c&0x0f])
+	}
+	return b.String()
+}
+
+// stripSpace 去掉所有空白，便于解码按行折断的 base64 等文本
+func stripSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// describeError 将标准库的解码错误转换为带位置的说明
+func describeError(err error) error {
+	var (
+		corrupt64 base64.CorruptInputError
+		corrupt32 base32.CorruptInputError
+		invalid   hex.InvalidByteError
+		escape    url.EscapeError
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &corrupt64):
+		return fmt.Errorf("invalid base64 data at offset %d", int64(corrupt64))
+	case errors.As(err, &corrupt32):
+		return fmt.Errorf("invalid base32 data at offset %d", int64(corrupt32))
+	case errors.As(err, &invalid):
+		return fmt.Errorf("invalid hex character %q", rune(invalid))
+	case errors.Is(err, hex.ErrLength):
+		return errors.New("hex data must have an even number of digits")
+	case errors.As(err, &escape):
+		return fmt.Errorf("invalid percent escape %q", string(escape))
+	default:
+		return err
+	}
+}
+
+func unknownEncoding(encoding string) error {
+	return fmt.Errorf("unknown encoding %q, must be one of %s", encoding, strings.Join(Encodings, ", "))
+}
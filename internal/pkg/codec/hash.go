@@ -0,0 +1,74 @@
+package codec
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"strings"
+)
+
+// 支持的摘要算法
+const (
+	MD5     = "md5"
+	SHA1    = "sha1"
+	SHA224  = "sha224"
+	SHA256  = "sha256"
+	SHA384  = "sha384"
+	SHA512  = "sha512"
+	CRC32   = "crc32"  // IEEE 多项式，与 zip、gzip 和 PNG 一致
+	CRC32C  = "crc32c" // Castagnoli 多项式，与 iSCSI、ext4 一致
+	Adler32 = "adler32"
+)
+
+// Algorithms 支持的摘要算法名称
+var Algorithms = []string{MD5, SHA1, SHA224, SHA256, SHA384, SHA512, CRC32, CRC32C, Adler32}
+
+// 密码学摘要算法，可以用于 HMAC
+var cryptoHashes = map[string]func() hash.Hash{
+	MD5:    md5.New,
+	SHA1:   sha1.New,
+	SHA224: sha256.New224,
+	SHA256: sha256.New,
+	SHA384: sha512.New384,
+	SHA512: sha512.New,
+}
+
+// 校验和算法
+var checksums = map[string]func() hash.Hash{
+	CRC32:   func() hash.Hash { return crc32.NewIEEE() },
+	CRC32C:  func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) },
+	Adler32: func() hash.Hash { return adler32.New() },
+}
+
+// Sum 计算 data 的摘要；key 不为 nil 时计算 HMAC，只有密码学摘要算法支持 HMAC
+func Sum(algorithm string, data, key []byte) ([]byte, error) {
+	algorithm = strings.ToLower(strings.ReplaceAll(algorithm, "-", ""))
+	var h hash.Hash
+	if newHash, ok := cryptoHashes[algorithm]; ok {
+		if key != nil {
+			h = hmac.New(newHash, key)
+		} else {
+			h = newHash()
+		}
+	} else if newChecksum, ok := checksums[algorithm]; ok {
+		if key != nil {
+			return nil, fmt.Errorf("HMAC requires a cryptographic hash, %s is a checksum", algorithm)
+		}
+		h = newChecksum()
+	} else {
+		return nil, fmt.Errorf("unknown algorithm %q, must be one of %s", algorithm, strings.Join(Algorithms, ", "))
+	}
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// Equal 以常量时间比较两个摘要
+func Equal(a, b []byte) bool {
+	return hmac.Equal(a, b)
+}
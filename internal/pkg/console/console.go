@@ -0,0 +1,92 @@
+// Package console 内置的 Web 控制台，用于在浏览器中手动调用工具
+package console
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"mcp-go-tutorials/internal/pkg/tool/manager"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+//go:embed static
+var assets embed.FS
+
+// Console Web 控制台
+type Console struct {
+	server      *server.MCPServer
+	toolManager *manager.Manager
+	requestID   atomic.Int64
+}
+
+// callRequest 控制台发起的工具调用请求
+type callRequest struct {
+	Name      string         `json:"name" binding:"required"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// NewConsole 创建 Web 控制台
+func NewConsole(s *server.MCPServer, tm *manager.Manager) *Console {
+	return &Console{
+		server:      s,
+		toolManager: tm,
+	}
+}
+
+// Register 将控制台页面和接口注册到路由，auth 只作用于接口，页面本身不包含敏感信息
+func (c *Console) Register(router gin.IRouter, path string, auth ...gin.HandlerFunc) {
+	static, _ := fs.Sub(assets, "static")
+
+	group := router.Group(path)
+	group.StaticFS("/ui", http.FS(static))
+	group.GET("", func(ctx *gin.Context) {
+		ctx.Redirect(http.StatusFound, ctx.Request.URL.Path+"/ui/")
+	})
+	api := group.Group("/api", auth...)
+	api.GET("/tools", c.listTools)
+	api.POST("/call", c.callTool)
+}
+
+// listTools 返回工具管理器中所有工具的定义
+func (c *Console) listTools(ctx *gin.Context) {
+	tools := make([]mcp.Tool, 0, len(c.toolManager.GetTools()))
+	for _, handler := range c.toolManager.GetTools() {
+		tools = append(tools, handler.Schema())
+	}
+	ctx.JSON(http.StatusOK, gin.H{"tools": tools})
+}
+
+// callTool 构造 JSON-RPC 请求并交给 MCP 服务器处理，返回原始请求和响应
+func (c *Console) callTool(ctx *gin.Context) {
+	var req callRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rpcRequest := mcp.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      mcp.NewRequestId(c.requestID.Add(1)),
+		Request: mcp.Request{Method: string(mcp.MethodToolsCall)},
+		Params: mcp.CallToolParams{
+			Name:      req.Name,
+			Arguments: req.Arguments,
+		},
+	}
+	raw, err := json.Marshal(rpcRequest)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := c.server.HandleMessage(ctx.Request.Context(), raw)
+	ctx.JSON(http.StatusOK, gin.H{
+		"request":  json.RawMessage(raw),
+		"response": response,
+	})
+}
@@ -0,0 +1,174 @@
+(function () {
+    "use strict";
+
+    // 控制台接口相对于页面 (/<console>/ui/) 的路径
+    const apiBase = "../api";
+
+    const toolList = document.getElementById("tool-list");
+    const detail = document.getElementById("tool-detail");
+    const form = document.getElementById("tool-form");
+    const requestView = document.getElementById("request");
+    const responseView = document.getElementById("response");
+
+    const tokenInput = document.getElementById("token");
+
+    let current = null;
+
+    function pretty(value) {
+        return JSON.stringify(value, null, 2);
+    }
+
+    // 接口需要 console.token，只保存在当前标签页中
+    function authHeaders(headers) {
+        return Object.assign({"Authorization": "Bearer " + (sessionStorage.getItem("token") || "")}, headers);
+    }
+
+    function checkAuth(resp) {
+        if (resp.status === 401) {
+            throw new Error("enter the console token at the top of the page");
+        }
+        return resp.json();
+    }
+
+    function loadTools() {
+        fetch(apiBase + "/tools", {headers: authHeaders()})
+            .then(checkAuth)
+            .then((data) => {
+                toolList.innerHTML = "";
+                (data.tools || []).forEach((tool) => {
+                    const item = document.createElement("li");
+                    item.textContent = tool.name;
+                    item.title = tool.description || "";
+                    item.addEventListener("click", () => selectTool(tool, item));
+                    toolList.appendChild(item);
+                });
+            })
+            .catch((err) => {
+                responseView.textContent = "Failed to load tools: " + err;
+            });
+    }
+
+    function selectTool(tool, item) {
+        current = tool;
+        toolList.querySelectorAll("li").forEach((li) => li.classList.remove("active"));
+        item.classList.add("active");
+
+        document.getElementById("tool-name").textContent = tool.name;
+        document.getElementById("tool-description").textContent = tool.description || "";
+        renderForm(tool.inputSchema || {});
+        detail.hidden = false;
+    }
+
+    // 根据工具的 inputSchema 渲染表单
+    function renderForm(schema) {
+        form.innerHTML = "";
+        const properties = schema.properties || {};
+        const required = schema.required || [];
+
+        Object.keys(properties).forEach((name) => {
+            const prop = properties[name];
+            const label = document.createElement("label");
+            label.textContent = name + (required.includes(name) ? " *" : "");
+            if (prop.description) {
+                const hint = document.createElement("span");
+                hint.className = "hint";
+                hint.textContent = " — " + prop.description;
+                label.appendChild(hint);
+            }
+            form.appendChild(label);
+            form.appendChild(createInput(name, prop));
+        });
+    }
+
+    function createInput(name, prop) {
+        let input;
+        if (Array.isArray(prop.enum)) {
+            input = document.createElement("select");
+            input.appendChild(new Option("", ""));
+            prop.enum.forEach((value) => input.appendChild(new Option(value, value)));
+        } else if (prop.type === "boolean") {
+            input = document.createElement("select");
+            ["", "true", "false"].forEach((value) => input.appendChild(new Option(value, value)));
+        } else if (prop.type === "object" || prop.type === "array") {
+            input = document.createElement("textarea");
+            input.rows = 4;
+            input.placeholder = prop.type === "array" ? "[ ]" : "{ }";
+        } else {
+            input = document.createElement("input");
+            input.type = prop.type === "number" || prop.type === "integer" ? "number" : "text";
+            if (prop.type === "number") {
+                input.step = "any";
+            }
+        }
+        if (prop.default !== undefined) {
+            input.value = typeof prop.default === "object" ? pretty(prop.default) : String(prop.default);
+        }
+        input.name = name;
+        input.dataset.type = prop.type || "string";
+        return input;
+    }
+
+    // 将表单值按 schema 类型转换为参数
+    function collectArguments() {
+        const args = {};
+        Array.from(form.elements).forEach((input) => {
+            if (input.value === "") {
+                return;
+            }
+            switch (input.dataset.type) {
+                case "number":
+                case "integer":
+                    args[input.name] = Number(input.value);
+                    break;
+                case "boolean":
+                    args[input.name] = input.value === "true";
+                    break;
+                case "object":
+                case "array":
+                    args[input.name] = JSON.parse(input.value);
+                    break;
+                default:
+                    args[input.name] = input.value;
+            }
+        });
+        return args;
+    }
+
+    function callTool() {
+        if (!current) {
+            return;
+        }
+        let args;
+        try {
+            args = collectArguments();
+        } catch (err) {
+            responseView.textContent = "Invalid JSON argument: " + err.message;
+            return;
+        }
+        requestView.textContent = "";
+        responseView.textContent = "Calling...";
+        fetch(apiBase + "/call", {
+            method: "POST",
+            headers: authHeaders({"Content-Type": "application/json"}),
+            body: JSON.stringify({name: current.name, arguments: args}),
+        })
+            .then(checkAuth)
+            .then((data) => {
+                requestView.textContent = data.request ? pretty(data.request) : "";
+                responseView.textContent = pretty(data.response || data);
+            })
+            .catch((err) => {
+                responseView.textContent = "Request failed: " + err;
+            });
+    }
+
+    document.getElementById("token-form").addEventListener("submit", (event) => {
+        event.preventDefault();
+        sessionStorage.setItem("token", tokenInput.value);
+        responseView.textContent = "";
+        loadTools();
+    });
+    document.getElementById("call-button").addEventListener("click", callTool);
+    tokenInput.value = sessionStorage.getItem("token") || "";
+    loadTools();
+})();
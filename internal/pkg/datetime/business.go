@@ -0,0 +1,112 @@
+package datetime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxBusinessDays 工作日计算允许的最大天数，防止过长的循环
+const MaxBusinessDays = 100000
+
+// Calendar 工作日历，周末和节假日不是工作日
+type Calendar struct {
+	weekend  [7]bool
+	holidays map[civilDate]bool
+}
+
+// civilDate 不带时区的日期
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{y, m, d}
+}
+
+// NewCalendar 创建工作日历，weekend 为周末的星期名称（如 saturday、sun），为空时使用周六和周日；holidays 为 2006-01-02 形式的日期
+func NewCalendar(weekend []string, holidays []string) (*Calendar, error) {
+	c := &Calendar{holidays: make(map[civilDate]bool, len(holidays))}
+	if len(weekend) == 0 {
+		c.weekend[time.Saturday] = true
+		c.weekend[time.Sunday] = true
+	}
+	for _, name := range weekend {
+		day, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		c.weekend[day] = true
+	}
+	if c.weekend == [7]bool{true, true, true, true, true, true, true} {
+		return nil, fmt.Errorf("weekend must leave at least one working day")
+	}
+	for _, h := range holidays {
+		t, err := time.Parse(time.DateOnly, strings.TrimSpace(h))
+		if err != nil {
+			return nil, fmt.Errorf("holiday %q must be in 2006-01-02 form", h)
+		}
+		c.holidays[dateOf(t)] = true
+	}
+	return c, nil
+}
+
+// parseWeekday 解析星期名称，支持全称和前三个字母
+func parseWeekday(name string) (time.Weekday, error) {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if lower == full || (len(lower) == 3 && strings.HasPrefix(full, lower)) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", name)
+}
+
+// IsBusinessDay t 所在的日期是否为工作日
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	return !c.weekend[t.Weekday()] && !c.holidays[dateOf(t)]
+}
+
+// AddBusinessDays 从 t 开始前进 n 个工作日（n 为负数时后退），起始日期本身不计入，时间部分保持不变
+// n 为 0 时返回 t，与电子表格的 WORKDAY 函数一致
+func (c *Calendar) AddBusinessDays(t time.Time, n int) (time.Time, error) {
+	if n > MaxBusinessDays || n < -MaxBusinessDays {
+		return time.Time{}, fmt.Errorf("days must be between %d and %d", -MaxBusinessDays, MaxBusinessDays)
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsBusinessDay(t) {
+			n--
+		}
+	}
+	return t, nil
+}
+
+// CountBusinessDays 统计 start 和 end 之间（包含两端）的工作日数，end 早于 start 时结果为负数
+// 与电子表格的 NETWORKDAYS 函数一致
+func (c *Calendar) CountBusinessDays(start, end time.Time) (int, error) {
+	sign := 1
+	if dateOf(end) != dateOf(start) && end.Before(start) {
+		sign, start, end = -1, end, start
+	}
+	first := time.Date(start.Year(), start.Month(), start.Day(), 12, 0, 0, 0, time.UTC)
+	last := time.Date(end.Year(), end.Month(), end.Day(), 12, 0, 0, 0, time.UTC)
+	if last.Sub(first) > MaxBusinessDays*24*time.Hour {
+		return 0, fmt.Errorf("the range must not exceed %d days", MaxBusinessDays)
+	}
+	count := 0
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			count++
+		}
+	}
+	return sign * count, nil
+}
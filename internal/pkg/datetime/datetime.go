@@ -0,0 +1,56 @@
+// Package datetime 日期时间的解析、格式化、时长运算和工作日计算
+// 内嵌 IANA 时区数据库，在没有 /usr/share/zoneinfo 的精简容器中也能使用时区
+package datetime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// fixedOffset 匹配 +08:00、-0530 和 UTC+8 形式的固定时差
+var fixedOffset = regexp.MustCompile(`^(?i:UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// LoadLocation 加载时区，支持 IANA 名称（如 Asia/Shanghai）、UTC、Local 和 +08:00 形式的固定时差，空字符串表示 UTC
+func LoadLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	switch strings.ToUpper(name) {
+	case "", "UTC", "Z", "GMT":
+		return time.UTC, nil
+	case "LOCAL":
+		return time.Local, nil
+	}
+	if m := fixedOffset.FindStringSubmatch(name); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes := 0
+		if m[3] != "" {
+			minutes, _ = strconv.Atoi(m[3])
+		}
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid UTC offset %q", name)
+		}
+		seconds := hours*3600 + minutes*60
+		if m[1] == "-" {
+			seconds = -seconds
+		}
+		return time.FixedZone(FormatOffset(seconds), seconds), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q, use an IANA name such as Europe/Berlin or an offset such as +08:00", name)
+	}
+	return loc, nil
+}
+
+// FormatOffset 将相对 UTC 的秒数格式化为 +08:00 形式
+func FormatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
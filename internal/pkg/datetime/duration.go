@@ -0,0 +1,266 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration 带日历分量的时长，年、月、日按日历计算（跨夏令时仍保持墙上时间），Clock 为精确的时钟时长
+// 所有分量的符号相同
+type Duration struct {
+	Years  int
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+// isoDuration 匹配 ISO 8601 时长，例如 P1Y2M10DT2H30M、P2W 和 PT0.5S
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// durationPart 匹配 1y2mo3d4h 形式中的一个分量
+var durationPart = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zµ]+)\s*`)
+
+// 时长单位，calendar 为 0 时是时钟单位
+var durationUnits = map[string]struct {
+	calendar int // 1 年、2 月、3 周、4 日
+	clock    time.Duration
+}{
+	"y": {calendar: 1}, "yr": {calendar: 1}, "year": {calendar: 1}, "years": {calendar: 1},
+	"mo": {calendar: 2}, "month": {calendar: 2}, "months": {calendar: 2},
+	"w": {calendar: 3}, "wk": {calendar: 3}, "week": {calendar: 3}, "weeks": {calendar: 3},
+	"d": {calendar: 4}, "day": {calendar: 4}, "days": {calendar: 4},
+	"h": {clock: time.Hour}, "hr": {clock: time.Hour}, "hour": {clock: time.Hour}, "hours": {clock: time.Hour},
+	"m": {clock: time.Minute}, "min": {clock: time.Minute}, "minute": {clock: time.Minute}, "minutes": {clock: time.Minute},
+	"s": {clock: time.Second}, "sec": {clock: time.Second}, "second": {clock: time.Second}, "seconds": {clock: time.Second},
+	"ms": {clock: time.Millisecond}, "us": {clock: time.Microsecond}, "µs": {clock: time.Microsecond}, "ns": {clock: time.Nanosecond},
+}
+
+// 日历分量的上限，约为 10000 年，超过时 time.AddDate 会溢出回绕
+const (
+	maxDurationYears  = 10000
+	maxDurationMonths = maxDurationYears * 12
+	maxDurationDays   = maxDurationYears * 366
+)
+
+// addCalendar 累加日历分量并检查上限，calendar 的取值与 durationUnits 相同
+func (d *Duration) addCalendar(number string, calendar int) error {
+	n, err := strconv.Atoi(number)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return err
+	}
+	switch {
+	case calendar == 1 && (err != nil || n > maxDurationYears-d.Years):
+		return fmt.Errorf("years exceed %d", maxDurationYears)
+	case calendar == 2 && (err != nil || n > maxDurationMonths-d.Months):
+		return fmt.Errorf("months exceed %d", maxDurationMonths)
+	case calendar == 3 && (err != nil || n > (maxDurationDays-d.Days)/7):
+		return fmt.Errorf("weeks and days exceed %d days", maxDurationDays)
+	case calendar == 4 && (err != nil || n > maxDurationDays-d.Days):
+		return fmt.Errorf("weeks and days exceed %d days", maxDurationDays)
+	}
+	switch calendar {
+	case 1:
+		d.Years += n
+	case 2:
+		d.Months += n
+	case 3:
+		d.Days += 7 * n
+	case 4:
+		d.Days += n
+	}
+	return nil
+}
+
+// ParseDuration 解析时长，支持 ISO 8601（P1Y2M3DT4H5M6S）和 1y2mo3w4d5h6m7s 形式，可以带 +/- 符号
+// 年、月、周、日必须是整数；m 表示分钟，mo 表示月
+func ParseDuration(s string) (Duration, error) {
+	value := strings.TrimSpace(s)
+	sign := 1
+	if rest, ok := strings.CutPrefix(value, "-"); ok {
+		sign, value = -1, rest
+	} else {
+		value = strings.TrimPrefix(value, "+")
+	}
+	if value == "" {
+		return Duration{}, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var (
+		d   Duration
+		err error
+	)
+	if strings.HasPrefix(value, "P") {
+		d, err = parseISODuration(value)
+	} else {
+		d, err = parseUnitDuration(strings.ToLower(value))
+	}
+	if err != nil {
+		return Duration{}, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	if sign < 0 {
+		d = d.Neg()
+	}
+	return d, nil
+}
+
+func parseISODuration(value string) (Duration, error) {
+	m := isoDuration.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return Duration{}, fmt.Errorf("not an ISO 8601 duration such as P1Y2M3DT4H5M6S")
+	}
+	var d Duration
+	// 年、月、周、日，周换算为 7 天
+	for i := range 4 {
+		if m[i+1] == "" {
+			continue
+		}
+		if err := d.addCalendar(m[i+1], i+1); err != nil {
+			return Duration{}, err
+		}
+	}
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if m[i+5] == "" {
+			continue
+		}
+		if err := d.addClock(m[i+5], unit); err != nil {
+			return Duration{}, err
+		}
+	}
+	return d, nil
+}
+
+func parseUnitDuration(value string) (Duration, error) {
+	var d Duration
+	for value != "" {
+		m := durationPart.FindStringSubmatch(value)
+		if m == nil {
+			return Duration{}, fmt.Errorf("expected a number followed by a unit at %q", value)
+		}
+		unit, ok := durationUnits[m[2]]
+		if !ok {
+			return Duration{}, fmt.Errorf("unknown unit %q, use y, mo, w, d, h, m, s, ms, us or ns", m[2])
+		}
+		value = value[len(m[0]):]
+
+		if unit.calendar == 0 {
+			if err := d.addClock(m[1], unit.clock); err != nil {
+				return Duration{}, err
+			}
+			continue
+		}
+		if strings.Contains(m[1], ".") {
+			return Duration{}, fmt.Errorf("%s%s: years, months, weeks and days must be whole numbers", m[1], m[2])
+		}
+		if err := d.addCalendar(m[1], unit.calendar); err != nil {
+			return Duration{}, err
+		}
+	}
+	return d, nil
+}
+
+// addClock 累加时钟分量并检查溢出
+func (d *Duration) addClock(number string, unit time.Duration) error {
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return err
+	}
+	total := float64(d.Clock) + f*float64(unit)
+	if total >= math.MaxInt64 {
+		return fmt.Errorf("the hours, minutes and seconds exceed %v", time.Duration(math.MaxInt64))
+	}
+	d.Clock = time.Duration(math.Round(total))
+	return nil
+}
+
+// Neg 返回相反的时长
+func (d Duration) Neg() Duration {
+	return Duration{Years: -d.Years, Months: -d.Months, Days: -d.Days, Clock: -d.Clock}
+}
+
+// IsZero 时长是否为 0
+func (d Duration) IsZero() bool {
+	return d == Duration{}
+}
+
+// AddTo 将时长加到 t 上，先按日历加年、月、日，再加时钟时长
+// 与 time.AddDate 相同，1 月 31 日加 1 个月会规范化为 3 月 3 日（或闰年的 3 月 2 日）
+func (d Duration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Days).Add(d.Clock)
+}
+
+// String 返回 ISO 8601 形式，例如 P1Y2M3DT4H5M6.5S，负数时长以 - 开头
+func (d Duration) String() string {
+	if d.IsZero() {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if d.Years < 0 || d.Months < 0 || d.Days < 0 || d.Clock < 0 {
+		b.WriteByte('-')
+		d = d.Neg()
+	}
+	b.WriteByte('P')
+	for _, part := range []struct {
+		n    int
+		unit byte
+	}{{d.Years, 'Y'}, {d.Months, 'M'}, {d.Days, 'D'}} {
+		if part.n != 0 {
+			b.WriteString(strconv.Itoa(part.n))
+			b.WriteByte(part.unit)
+		}
+	}
+	if d.Clock != 0 {
+		b.WriteByte('T')
+		hours := d.Clock / time.Hour
+		minutes := d.Clock % time.Hour / time.Minute
+		seconds := d.Clock % time.Minute
+		if hours != 0 {
+			b.WriteString(strconv.FormatInt(int64(hours), 10) + "H")
+		}
+		if minutes != 0 {
+			b.WriteString(strconv.FormatInt(int64(minutes), 10) + "M")
+		}
+		if seconds != 0 {
+			b.WriteString(strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	return b.String()
+}
+
+// Between 返回从 a 到 b 的日历时长，满足 Between(a, b).AddTo(a) == b
+// 先取尽可能多的整月，再取整天，剩余部分为时钟时长；b 早于 a 时所有分量为负
+func Between(a, b time.Time) Duration {
+	b = b.In(a.Location())
+	sign := 1
+	if b.Before(a) {
+		sign = -1
+	}
+	// beyond 判断 t 是否越过了 b
+	beyond := func(t time.Time) bool {
+		if sign > 0 {
+			return t.After(b)
+		}
+		return t.Before(b)
+	}
+
+	months := sign * ((b.Year()-a.Year())*12 + int(b.Month()-a.Month()))
+	for months > 0 && beyond(a.AddDate(0, sign*months, 0)) {
+		months--
+	}
+	anchor := a.AddDate(0, sign*months, 0)
+	days := int(math.Abs(b.Sub(anchor).Hours()) / 24)
+	for days > 0 && beyond(anchor.AddDate(0, 0, sign*days)) {
+		days--
+	}
+	for !beyond(anchor.AddDate(0, 0, sign*(days+1))) {
+		days++
+	}
+	anchor = anchor.AddDate(0, 0, sign*days)
+
+	months, days = sign*months, sign*days
+	return Duration{Years: months / 12, Months: months % 12, Days: days, Clock: b.Sub(anchor)}
+}
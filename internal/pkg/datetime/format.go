@@ -0,0 +1,148 @@
+package datetime
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// namedFormats 命名的输出格式
+var namedFormats = map[string]string{
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"iso8601":     time.RFC3339,
+	"rfc1123":     time.RFC1123,
+	"rfc1123z":    time.RFC1123Z,
+	"rfc822":      time.RFC822,
+	"rfc822z":     time.RFC822Z,
+	"rfc850":      time.RFC850,
+	"ansic":       time.ANSIC,
+	"unixdate":    time.UnixDate,
+	"rubydate":    time.RubyDate,
+	"kitchen":     time.Kitchen,
+	"date":        time.DateOnly,
+	"time":        time.TimeOnly,
+	"datetime":    time.DateTime,
+}
+
+// FormatNames 返回命名格式的名称，包括 unix、unix_ms 和 unix_ns
+func FormatNames() []string {
+	names := make([]string, 0, len(namedFormats)+3)
+	for name := range namedFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append(names, "unix", "unix_ms", "unix_ns")
+}
+
+// Format 格式化时间，format 可以是命名格式（如 rfc3339、unix）、包含 % 的 strftime 格式（如 %Y-%m-%d %H:%M）
+// 或 Go 的参考时间格式（如 2006-01-02 15:04）
+func Format(t time.Time, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case "unix_ms":
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	case "unix_ns":
+		return strconv.FormatInt(t.UnixNano(), 10), nil
+	}
+	if layout, ok := namedFormats[strings.ToLower(format)]; ok {
+		return t.Format(layout), nil
+	}
+	if strings.Contains(format, "%") {
+		return strftime(t, format)
+	}
+	if format == "" {
+		return "", fmt.Errorf("format must not be empty")
+	}
+	return t.Format(format), nil
+}
+
+// strftime 按 C 的 strftime 格式化时间，支持常用的转换说明符
+func strftime(t time.Time, format string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(format) {
+			return "", fmt.Errorf("format %q ends with a lone %%", format)
+		}
+		switch format[i] {
+		case 'Y':
+			b.WriteString(strconv.Itoa(t.Year()))
+		case 'y':
+			b.WriteString(t.Format("06"))
+		case 'm':
+			b.WriteString(t.Format("01"))
+		case 'd':
+			b.WriteString(t.Format("02"))
+		case 'e':
+			b.WriteString(t.Format("_2"))
+		case 'H':
+			b.WriteString(t.Format("15"))
+		case 'I':
+			b.WriteString(t.Format("03"))
+		case 'M':
+			b.WriteString(t.Format("04"))
+		case 'S':
+			b.WriteString(t.Format("05"))
+		case 'f':
+			fmt.Fprintf(&b, "%06d", t.Nanosecond()/1000)
+		case 'L':
+			fmt.Fprintf(&b, "%03d", t.Nanosecond()/1e6)
+		case 'p':
+			b.WriteString(t.Format("PM"))
+		case 'a':
+			b.WriteString(t.Format("Mon"))
+		case 'A':
+			b.WriteString(t.Format("Monday"))
+		case 'b', 'h':
+			b.WriteString(t.Format("Jan"))
+		case 'B':
+			b.WriteString(t.Format("January"))
+		case 'j':
+			fmt.Fprintf(&b, "%03d", t.YearDay())
+		case 'u':
+			b.WriteString(strconv.Itoa((int(t.Weekday())+6)%7 + 1))
+		case 'w':
+			b.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'V':
+			_, week := t.ISOWeek()
+			fmt.Fprintf(&b, "%02d", week)
+		case 'G':
+			year, _ := t.ISOWeek()
+			b.WriteString(strconv.Itoa(year))
+		case 'z':
+			b.WriteString(t.Format("-0700"))
+		case 'Z':
+			b.WriteString(t.Format("MST"))
+		case 's':
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'F':
+			b.WriteString(t.Format(time.DateOnly))
+		case 'T':
+			b.WriteString(t.Format(time.TimeOnly))
+		case 'R':
+			b.WriteString(t.Format("15:04"))
+		case 'D':
+			b.WriteString(t.Format("01/02/06"))
+		case 'c':
+			b.WriteString(t.Format(time.ANSIC))
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case '%':
+			b.WriteByte('%')
+		default:
+			return "", fmt.Errorf("unsupported strftime directive %%%c", format[i])
+		}
+	}
+	return b.String(), nil
+}
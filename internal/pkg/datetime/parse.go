@@ -0,0 +1,222 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseOptions 解析日期时间的选项
+type ParseOptions struct {
+	Location *time.Location // 输入中没有时区时使用的时区，为 nil 时使用 UTC
+	DayFirst bool           // 01/02/2006 按日/月/年解析，默认按美式的月/日/年
+	Now      time.Time      // now、today 等相对时间的基准，为零值时使用当前时间
+}
+
+// Parsed 解析结果
+type Parsed struct {
+	Time   time.Time
+	Format string // 匹配的格式名称，例如 rfc3339、unix 和 2006-01-02
+	// Ambiguous 为 true 时，输入按日/月和月/日都能解析且结果不同，调用方需要用 DayFirst 明确
+	Ambiguous bool
+}
+
+// layout 支持的输入格式
+type layout struct {
+	name   string
+	layout string
+	// dateless 只有时间的格式，日期取 Now 所在的日期
+	dateless bool
+}
+
+// 常用的输入格式，按顺序尝试；带秒的格式同时接受小数秒
+var layouts = []layout{
+	{name: "rfc3339", layout: time.RFC3339Nano},
+	{name: "2006-01-02T15:04:05", layout: "2006-01-02T15:04:05"},
+	{name: "2006-01-02T15:04", layout: "2006-01-02T15:04"},
+	{name: "2006-01-02 15:04:05Z07:00", layout: "2006-01-02 15:04:05Z07:00"},
+	{name: "2006-01-02 15:04:05 -0700", layout: "2006-01-02 15:04:05 -0700"},
+	{name: "2006-01-02 15:04:05 MST", layout: "2006-01-02 15:04:05 MST"},
+	{name: "2006-01-02 15:04:05", layout: "2006-01-02 15:04:05"},
+	{name: "2006-01-02 15:04", layout: "2006-01-02 15:04"},
+	{name: "2006-01-02", layout: "2006-01-02"},
+	{name: "2006/01/02 15:04:05", layout: "2006/01/02 15:04:05"},
+	{name: "2006/01/02 15:04", layout: "2006/01/02 15:04"},
+	{name: "2006/01/02", layout: "2006/01/02"},
+	{name: "20060102T150405Z0700", layout: "20060102T150405Z0700"},
+	{name: "20060102T150405", layout: "20060102T150405"},
+	{name: "20060102", layout: "20060102"},
+	{name: "rfc1123", layout: time.RFC1123},
+	{name: "rfc1123z", layout: time.RFC1123Z},
+	{name: "rfc850", layout: time.RFC850},
+	{name: "rfc822", layout: time.RFC822},
+	{name: "rfc822z", layout: time.RFC822Z},
+	{name: "ansic", layout: time.ANSIC},
+	{name: "unixdate", layout: time.UnixDate},
+	{name: "rubydate", layout: time.RubyDate},
+	{name: "Mon, 2 Jan 2006 15:04:05 MST", layout: "Mon, 2 Jan 2006 15:04:05 MST"},
+	{name: "Mon, 2 Jan 2006 15:04:05 -0700", layout: "Mon, 2 Jan 2006 15:04:05 -0700"},
+	{name: "Monday, January 2, 2006", layout: "Monday, January 2, 2006"},
+	{name: "Monday, January 2, 2006 15:04", layout: "Monday, January 2, 2006 15:04"},
+	{name: "January 2, 2006 15:04:05", layout: "January 2, 2006 15:04:05"},
+	{name: "January 2, 2006 3:04 PM", layout: "January 2, 2006 3:04 PM"},
+	{name: "January 2, 2006", layout: "January 2, 2006"},
+	{name: "Jan 2, 2006 15:04:05", layout: "Jan 2, 2006 15:04:05"},
+	{name: "Jan 2, 2006 3:04 PM", layout: "Jan 2, 2006 3:04 PM"},
+	{name: "Jan 2, 2006", layout: "Jan 2, 2006"},
+	{name: "Jan 2 2006", layout: "Jan 2 2006"},
+	{name: "2 January 2006 15:04", layout: "2 January 2006 15:04"},
+	{name: "2 January 2006", layout: "2 January 2006"},
+	{name: "2 Jan 2006 15:04:05", layout: "2 Jan 2006 15:04:05"},
+	{name: "2 Jan 2006", layout: "2 Jan 2006"},
+	{name: "02-Jan-2006 15:04:05", layout: "02-Jan-2006 15:04:05"},
+	{name: "02-Jan-2006", layout: "02-Jan-2006"},
+	{name: "02.01.2006 15:04:05", layout: "02.01.2006 15:04:05"},
+	{name: "02.01.2006 15:04", layout: "02.01.2006 15:04"},
+	{name: "02.01.2006", layout: "02.01.2006"},
+	{name: "15:04:05", layout: "15:04:05", dateless: true},
+	{name: "15:04", layout: "15:04", dateless: true},
+	{name: "3:04 PM", layout: "3:04 PM", dateless: true},
+	{name: "3:04PM", layout: "3:04PM", dateless: true},
+	{name: "3PM", layout: "3PM", dateless: true},
+}
+
+// 斜杠和横线分隔的数字日期，月/日和日/月的顺序有歧义
+var (
+	monthFirstLayouts = []layout{
+		{name: "01/02/2006 15:04:05", layout: "1/2/2006 15:04:05"},
+		{name: "01/02/2006 15:04", layout: "1/2/2006 15:04"},
+		{name: "01/02/2006 3:04 PM", layout: "1/2/2006 3:04 PM"},
+		{name: "01/02/2006", layout: "1/2/2006"},
+		{name: "01-02-2006", layout: "1-2-2006"},
+	}
+	dayFirstLayouts = []layout{
+		{name: "02/01/2006 15:04:05", layout: "2/1/2006 15:04:05"},
+		{name: "02/01/2006 15:04", layout: "2/1/2006 15:04"},
+		{name: "02/01/2006 3:04 PM", layout: "2/1/2006 3:04 PM"},
+		{name: "02/01/2006", layout: "2/1/2006"},
+		{name: "02-01-2006", layout: "2-1-2006"},
+	}
+)
+
+// Layouts 返回支持的输入格式名称
+func Layouts() []string {
+	names := []string{"now", "today", "tomorrow", "yesterday", "unix"}
+	for _, l := range layouts {
+		names = append(names, l.name)
+	}
+	for _, l := range monthFirstLayouts {
+		names = append(names, l.name)
+	}
+	for _, l := range dayFirstLayouts {
+		names = append(names, l.name)
+	}
+	return names
+}
+
+// Parse 按常用格式解析日期时间，也支持 Unix 时间戳（秒、毫秒、微秒或纳秒）和 now、today 等相对日期
+func Parse(value string, opts ParseOptions) (Parsed, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	now = now.In(loc)
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Parsed{}, errors.New("date must not be empty")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	switch strings.ToLower(value) {
+	case "now":
+		return Parsed{Time: now, Format: "now"}, nil
+	case "today":
+		return Parsed{Time: today, Format: "today"}, nil
+	case "tomorrow":
+		return Parsed{Time: today.AddDate(0, 0, 1), Format: "tomorrow"}, nil
+	case "yesterday":
+		return Parsed{Time: today.AddDate(0, 0, -1), Format: "yesterday"}, nil
+	}
+	if t, ok, err := parseUnix(value); ok {
+		if err != nil {
+			return Parsed{}, err
+		}
+		return Parsed{Time: t.In(loc), Format: "unix"}, nil
+	}
+
+	for _, l := range layouts {
+		t, err := time.ParseInLocation(l.layout, value, loc)
+		if err != nil {
+			continue
+		}
+		if l.dateless {
+			t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+		}
+		return Parsed{Time: t, Format: l.name}, nil
+	}
+
+	primary, alternate := monthFirstLayouts, dayFirstLayouts
+	if opts.DayFirst {
+		primary, alternate = alternate, primary
+	}
+	if p, ok := parseLayouts(primary, value, loc); ok {
+		// 两种顺序都能解析且结果不同时，说明 03/04/2025 这样的输入有歧义
+		if q, ok := parseLayouts(alternate, value, loc); ok && !q.Time.Equal(p.Time) {
+			p.Ambiguous = true
+		}
+		return p, nil
+	}
+	// 按指定顺序无法解析时，例如月份大于 12，使用另一种顺序
+	if p, ok := parseLayouts(alternate, value, loc); ok {
+		return p, nil
+	}
+	return Parsed{}, fmt.Errorf("unrecognized date %q, use a format such as 2006-01-02, 2006-01-02T15:04:05Z07:00 or a Unix timestamp", value)
+}
+
+func parseLayouts(candidates []layout, value string, loc *time.Location) (Parsed, bool) {
+	for _, l := range candidates {
+		if t, err := time.ParseInLocation(l.layout, value, loc); err == nil {
+			return Parsed{Time: t, Format: l.name}, true
+		}
+	}
+	return Parsed{}, false
+}
+
+// parseUnix 解析 Unix 时间戳，按数值大小判断单位：小于 1e11 为秒，其次依次为毫秒、微秒和纳秒
+// 第二个返回值表示输入是否为数字
+func parseUnix(value string) (time.Time, bool, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || strings.ContainsAny(value, "eEnNiI") {
+		return time.Time{}, false, nil
+	}
+	// 8 位的数字更可能是 20060102 形式的日期
+	if len(strings.TrimLeft(value, "+-")) == 8 && !strings.Contains(value, ".") {
+		return time.Time{}, false, nil
+	}
+	abs := math.Abs(f)
+	switch {
+	case abs < 1e11:
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(math.Round(frac*1e9))), true, nil
+	case abs < 1e14:
+		return time.UnixMicro(int64(math.Round(f * 1e3))), true, nil
+	case abs < 1e17:
+		return time.UnixMicro(int64(math.Round(f))), true, nil
+	case abs < 9.2e18:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, true, fmt.Errorf("invalid nanosecond timestamp %q", value)
+		}
+		return time.Unix(0, n), true, nil
+	default:
+		return time.Time{}, true, fmt.Errorf("timestamp %q is out of range", value)
+	}
+}
@@ -0,0 +1,199 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// Encode 将文档值编码为指定格式，indent 为缩进的空格数；JSON 的 indent 为 0 时输出压缩形式
+func Encode(v any, format string, indent int) (string, error) {
+	switch format {
+	case JSON:
+		return encodeJSONDocument(v, indent)
+	case YAML:
+		return encodeYAML(v, indent)
+	case TOML:
+		return encodeTOML(v, indent)
+	default:
+		return "", fmt.Errorf("unknown format %q, must be one of json, yaml, toml", format)
+	}
+}
+
+// CheckJSON 检查值能否表示为 JSON，YAML 和 TOML 中的无穷大和 NaN 不能
+func CheckJSON(v any) error {
+	return walk(v, "$", func(path string, v any) error {
+		if f, ok := v.(float64); ok && (math.IsInf(f, 0) || math.IsNaN(f)) {
+			return fmt.Errorf("%s: JSON cannot represent %v", path, f)
+		}
+		return nil
+	})
+}
+
+// walk 深度优先遍历值，path 为 JSONPath 形式的位置
+func walk(v any, path string, fn func(path string, v any) error) error {
+	if err := fn(path, v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case *Object:
+		for _, key := range v.keys {
+			if err := walk(v.values[key], childPath(path, key), fn); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if err := walk(item, indexPath(path, i), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func encodeJSONDocument(v any, indent int) (string, error) {
+	if err := CheckJSON(v); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, v); err != nil {
+		return "", err
+	}
+	if indent <= 0 {
+		return buf.String(), nil
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", strings.Repeat(" ", indent)); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func encodeYAML(v any, indent int) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(max(indent, 2))
+	if err := enc.Encode(yamlNode(v)); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// yamlNode 将文档值转换为 yaml.Node，标量显式设置标签，字符串 "true"、"1" 等会被加上引号
+func yamlNode(v any) *yaml.Node {
+	switch v := v.(type) {
+	case *Object:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if v.Len() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, key := range v.keys {
+			keyNode := &yaml.Node{}
+			keyNode.SetString(key)
+			node.Content = append(node.Content, keyNode, yamlNode(v.values[key]))
+		}
+		return node
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if len(v) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for _, item := range v {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case string:
+		node := &yaml.Node{}
+		node.SetString(v)
+		return node
+	case json.Number:
+		// 不设置标签，超出 64 位的整数也以普通数值输出
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: string(v)}
+	case float64:
+		value := ".nan"
+		switch {
+		case math.IsInf(v, 1):
+			value = ".inf"
+		case math.IsInf(v, -1):
+			value = "-.inf"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: value}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
+
+func encodeTOML(v any, indent int) (string, error) {
+	obj, ok := v.(*Object)
+	if !ok {
+		return "", fmt.Errorf("a TOML document must be a table (object), got %s", TypeName(v))
+	}
+	table, err := toTOML(obj, "$")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.SetIndentSymbol(strings.Repeat(" ", max(indent, 0)))
+	enc.SetIndentTables(indent > 0)
+	if err := enc.Encode(table); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// toTOML 将文档值转换为 TOML 编码器接受的值；TOML 没有 null，整数限制为 64 位
+func toTOML(v any, path string) (any, error) {
+	switch v := v.(type) {
+	case *Object:
+		table := make(map[string]any, v.Len())
+		for _, key := range v.keys {
+			value, err := toTOML(v.values[key], childPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			table[key] = value
+		}
+		return table, nil
+	case []any:
+		array := make([]any, len(v))
+		for i, item := range v {
+			value, err := toTOML(item, indexPath(path, i))
+			if err != nil {
+				return nil, err
+			}
+			array[i] = value
+		}
+		return array, nil
+	case json.Number:
+		if !strings.ContainsAny(string(v), ".eE") {
+			n, ok := new(big.Int).SetString(string(v), 10)
+			if !ok || !n.IsInt64() {
+				return nil, fmt.Errorf("%s: TOML integers are limited to 64 bits, got %s", path, v)
+			}
+			return n.Int64(), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s is out of the float64 range", path, v)
+		}
+		return f, nil
+	case nil:
+		return nil, fmt.Errorf("%s: TOML has no null value", path)
+	default:
+		return v, nil
+	}
+}
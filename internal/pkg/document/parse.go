@@ -0,0 +1,399 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// 支持的文档格式
+const (
+	Auto = "auto" // 依次尝试 JSON、TOML 和 YAML
+	JSON = "json"
+	YAML = "yaml"
+	TOML = "toml"
+)
+
+// Formats 支持的文档格式名称
+var Formats = []string{JSON, YAML, TOML}
+
+// 解析限制，防止过深的嵌套和 YAML 别名展开导致的内存耗尽
+const (
+	MaxDepth  = 1000
+	MaxValues = 1000000
+)
+
+// SyntaxError 带位置的解析错误，Line 和 Column 从 1 开始，位置未知时为 0
+type SyntaxError struct {
+	Format string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("invalid %s at line %d, column %d: %s", e.Format, e.Line, e.Column, e.Msg)
+	case e.Line > 0:
+		return fmt.Sprintf("invalid %s at line %d: %s", e.Format, e.Line, e.Msg)
+	default:
+		return fmt.Sprintf("invalid %s: %s", e.Format, e.Msg)
+	}
+}
+
+// Parse 按格式解析文档，format 为 auto 时自动识别；返回解析结果和实际使用的格式
+func Parse(data []byte, format string) (any, string, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, format, errors.New("the document is empty")
+	}
+	switch format {
+	case JSON:
+		v, err := parseJSON(data)
+		return v, format, err
+	case YAML:
+		v, err := parseYAML(data)
+		return v, format, err
+	case TOML:
+		v, err := parseTOML(data)
+		return v, format, err
+	case Auto, "":
+		return parseAuto(data)
+	default:
+		return nil, format, fmt.Errorf("unknown format %q, must be one of json, yaml, toml", format)
+	}
+}
+
+// parseAuto 依次尝试 JSON、TOML 和 YAML；YAML 几乎能接受任何文本，因此放在最后
+// 以 { 或 [ 开头的文本按 JSON 处理（[ 开头的也可能是 TOML 的表头），不再尝试 YAML 的流式写法，
+// 否则缺少值的 JSON 会被当作合法的 YAML
+func parseAuto(data []byte) (any, string, error) {
+	jsonValue, jsonErr := parseJSON(data)
+	if jsonErr == nil {
+		return jsonValue, JSON, nil
+	}
+	if v, err := parseTOML(data); err == nil {
+		return v, TOML, nil
+	}
+	if first := bytes.TrimSpace(data)[0]; first == '{' || first == '[' {
+		return nil, JSON, jsonErr
+	}
+	v, err := parseYAML(data)
+	return v, YAML, err
+}
+
+// parseJSON 解析 JSON，保持键的顺序，数值保持原始的十进制表示
+func parseJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := &jsonParser{dec: dec}
+	v, err := p.value(0)
+	if err == nil {
+		if _, tokenErr := dec.Token(); tokenErr != io.EOF {
+			err = fmt.Errorf("unexpected data after the top-level value")
+		}
+	}
+	if err != nil {
+		return nil, jsonError(data, dec.InputOffset(), err)
+	}
+	return v, nil
+}
+
+type jsonParser struct {
+	dec *json.Decoder
+}
+
+func (p *jsonParser) value(depth int) (any, error) {
+	token, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	if depth >= MaxDepth {
+		return nil, fmt.Errorf("nesting exceeds %d levels", MaxDepth)
+	}
+	switch delim {
+	case '{':
+		obj := NewObject()
+		for p.dec.More() {
+			key, err := p.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := p.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(key.(string), value)
+		}
+		_, err := p.dec.Token()
+		return obj, err
+	case '[':
+		array := []any{}
+		for p.dec.More() {
+			value, err := p.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := p.dec.Token()
+		return array, err
+	default:
+		return nil, fmt.Errorf("unexpected %q", delim)
+	}
+}
+
+// jsonError 将错误转换为带行列号的 SyntaxError
+func jsonError(data []byte, offset int64, err error) error {
+	var syntaxErr *json.SyntaxError
+	msg := err.Error()
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	} else if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		offset, msg = int64(len(data)), "unexpected end of input"
+	}
+	line, column := position(data, offset)
+	return &SyntaxError{Format: JSON, Line: line, Column: column, Msg: msg}
+}
+
+// position 将字节偏移转换为行列号
+func position(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = len(bytes.Runes(before[bytes.LastIndexByte(before, '\n')+1:])) + 1
+	return line, column
+}
+
+// yamlLine 匹配 yaml 错误信息中的行号
+var yamlLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// parseYAML 解析单个 YAML 文档，保持键的顺序并展开别名和合并键
+func parseYAML(data []byte) (any, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var root yaml.Node
+	if err := dec.Decode(&root); err != nil {
+		return nil, yamlError(err)
+	}
+	var next yaml.Node
+	if err := dec.Decode(&next); err != io.EOF {
+		if err != nil {
+			return nil, yamlError(err)
+		}
+		return nil, &SyntaxError{Format: YAML, Line: next.Line, Msg: "multiple documents are not supported"}
+	}
+	c := &yamlConverter{merging: map[*yaml.Node]bool{}}
+	return c.convert(&root, 0)
+}
+
+func yamlError(err error) error {
+	msg := err.Error()
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return &SyntaxError{Format: YAML, Line: line, Msg: msg[len(m[0]):]}
+	}
+	return &SyntaxError{Format: YAML, Msg: strings.TrimPrefix(msg, "yaml: ")}
+}
+
+// yamlConverter 将 yaml.Node 转换为文档值，count 统计生成的值的数量，限制别名展开的规模
+type yamlConverter struct {
+	count int
+	// merging 正在展开的映射，合并键引用其中之一时会无限递归
+	merging map[*yaml.Node]bool
+}
+
+func (c *yamlConverter) convert(node *yaml.Node, depth int) (any, error) {
+	c.count++
+	if c.count > MaxValues {
+		return nil, &SyntaxError{Format: YAML, Line: node.Line, Msg: fmt.Sprintf("the document expands to more than %d values", MaxValues)}
+	}
+	if depth > MaxDepth {
+		return nil, &SyntaxError{Format: YAML, Line: node.Line, Msg: fmt.Sprintf("nesting exceeds %d levels", MaxDepth)}
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return c.convert(node.Content[0], depth)
+	case yaml.AliasNode:
+		return c.convert(node.Alias, depth+1)
+	case yaml.SequenceNode:
+		array := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			v, err := c.convert(item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		}
+		return array, nil
+	case yaml.MappingNode:
+		obj := NewObject()
+		if err := c.mapping(obj, node, depth); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	default:
+		return c.scalar(node)
+	}
+}
+
+// mapping 将映射的键值对加入 obj，合并键（<<）引入的键不覆盖映射自身的键
+func (c *yamlConverter) mapping(obj *Object, node *yaml.Node, depth int) error {
+	if depth > MaxDepth {
+		return &SyntaxError{Format: YAML, Line: node.Line, Msg: fmt.Sprintf("nesting exceeds %d levels", MaxDepth)}
+	}
+	c.merging[node] = true
+	defer delete(c.merging, node)
+
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge" {
+			merges = append(merges, value)
+			continue
+		}
+		if key.Kind != yaml.ScalarNode {
+			return &SyntaxError{Format: YAML, Line: key.Line, Column: key.Column, Msg: "mapping keys must be scalars"}
+		}
+		v, err := c.convert(value, depth+1)
+		if err != nil {
+			return err
+		}
+		obj.Set(key.Value, v)
+	}
+	for _, merge := range merges {
+		sources := []*yaml.Node{merge}
+		if merge.Kind == yaml.SequenceNode {
+			sources = merge.Content
+		}
+		for _, source := range sources {
+			for source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind != yaml.MappingNode {
+				return &SyntaxError{Format: YAML, Line: source.Line, Column: source.Column, Msg: "merge key << requires a mapping or a list of mappings"}
+			}
+			if c.merging[source] {
+				return &SyntaxError{Format: YAML, Line: merge.Line, Column: merge.Column, Msg: "merge key << refers to a mapping that contains it"}
+			}
+			// 合并进来的映射不经过 convert，在这里计数
+			c.count++
+			if c.count > MaxValues {
+				return &SyntaxError{Format: YAML, Line: source.Line, Msg: fmt.Sprintf("the document expands to more than %d values", MaxValues)}
+			}
+			merged := NewObject()
+			if err := c.mapping(merged, source, depth+1); err != nil {
+				return err
+			}
+			for _, key := range merged.Keys() {
+				if _, exists := obj.Get(key); !exists {
+					v, _ := merged.Get(key)
+					obj.Set(key, v)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// jsonNumber 匹配 JSON 数值字面量
+var jsonNumber = regexp.MustCompile(`^-?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][+-]?\d+)?$`)
+
+// scalar 按 YAML 解析出的标签转换标量，时间戳和二进制数据保持为字符串
+func (c *yamlConverter) scalar(node *yaml.Node) (any, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		err := node.Decode(&b)
+		return b, err
+	case "!!int", "!!float":
+		if jsonNumber.MatchString(node.Value) {
+			return json.Number(node.Value), nil
+		}
+		var v any
+		if err := node.Decode(&v); err != nil {
+			return nil, &SyntaxError{Format: YAML, Line: node.Line, Column: node.Column, Msg: err.Error()}
+		}
+		switch n := v.(type) {
+		case int:
+			return json.Number(strconv.Itoa(n)), nil
+		case int64:
+			return json.Number(strconv.FormatInt(n, 10)), nil
+		case uint64:
+			return json.Number(strconv.FormatUint(n, 10)), nil
+		case float64:
+			return floatNumber(n), nil
+		}
+		return node.Value, nil
+	default:
+		return node.Value, nil
+	}
+}
+
+// parseTOML 解析 TOML，TOML 解析器不保留键的顺序，因此对象的键按字典序排列
+func parseTOML(data []byte) (any, error) {
+	var table map[string]any
+	if err := toml.Unmarshal(data, &table); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			line, column := decodeErr.Position()
+			return nil, &SyntaxError{Format: TOML, Line: line, Column: column, Msg: strings.TrimPrefix(decodeErr.Error(), "toml: ")}
+		}
+		return nil, &SyntaxError{Format: TOML, Msg: strings.TrimPrefix(err.Error(), "toml: ")}
+	}
+	return fromTOML(table), nil
+}
+
+// fromTOML 将 TOML 解码结果转换为文档值，日期和时间转换为 RFC 3339 形式的字符串
+func fromTOML(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		obj := NewObject()
+		for _, key := range keys {
+			obj.Set(key, fromTOML(v[key]))
+		}
+		return obj
+	case []any:
+		array := make([]any, len(v))
+		for i, item := range v {
+			array[i] = fromTOML(item)
+		}
+		return array
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case float64:
+		return floatNumber(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		// toml.LocalDate、LocalTime 和 LocalDateTime
+		return v.String()
+	default:
+		return v
+	}
+}
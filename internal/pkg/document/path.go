@@ -0,0 +1,901 @@
+package document
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match 查询结果，Path 为匹配值的规范化 JSONPath
+type Match struct {
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// Path 编译后的 JSONPath 表达式
+//
+// 支持 RFC 9535 的常用部分：$、@、.name、['name']、[0]、[-1]、[start:end:step]、*、..（递归下降）、
+// [a,b] 并集和 [?filter] 过滤器。过滤器支持 ==、!=、<、<=、>、>=、=~（正则匹配）、&&、||、! 和括号。
+// 为了方便也接受 jq 风格的写法：.a.b 等价于 $.a.b，[] 等价于 [*]。
+type Path struct {
+	source   string
+	segments []segment
+}
+
+// PathError 带位置的 JSONPath 语法错误，Pos 从 1 开始，以字符计
+type PathError struct {
+	Pos int
+	Msg string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("invalid path at position %d: %s", e.Pos, e.Msg)
+}
+
+// segment 路径中的一段，descendant 为 true 时作用于所有后代
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// selector 从一个节点选出子节点
+type selector interface {
+	apply(node Match, e *evaluator, out []Match) []Match
+}
+
+// MaxQuerySteps 一次查询的求值步数上限，过滤器中的子查询也计算在内。
+// 每访问一个节点计 1 步，规范化路径每 64 字节再计 1 步，深层节点的路径拼接同样计入
+const MaxQuerySteps = 10000000
+
+// errQuerySteps 查询超出 MaxQuerySteps
+var errQuerySteps = fmt.Errorf("the query needs more than %d evaluation steps", MaxQuerySteps)
+
+// evaluator 一次查询的求值状态
+type evaluator struct {
+	ctx   context.Context
+	root  any
+	steps int
+	err   error
+	// absolute 以 $ 开头的子查询结果，与当前节点无关，每次查询只求值一次
+	absolute map[*queryOperand][]Match
+}
+
+// step 记录访问了 n 个节点，超出预算或 ctx 结束时记录错误并返回 false
+func (e *evaluator) step(n int) bool {
+	if e.err != nil {
+		return false
+	}
+	before := e.steps
+	e.steps += n
+	if e.steps > MaxQuerySteps {
+		e.err = errQuerySteps
+		return false
+	}
+	// 每约 4096 步检查一次 ctx
+	if e.steps>>12 != before>>12 {
+		if err := e.ctx.Err(); err != nil {
+			e.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// visit 记录访问了一个节点
+func (e *evaluator) visit(node Match) bool {
+	return e.step(1 + len(node.Path)>>6)
+}
+
+// CompilePath 编译 JSONPath 表达式
+func CompilePath(source string) (*Path, error) {
+	expr := strings.TrimSpace(source)
+	offset := strings.Index(source, expr)
+	switch {
+	case expr == "":
+		return nil, &PathError{Pos: 1, Msg: "the path is empty"}
+	case strings.HasPrefix(expr, "$"):
+	case strings.HasPrefix(expr, ".") || strings.HasPrefix(expr, "["):
+		// jq 风格，省略了 $
+		expr, offset = "$"+expr, offset-1
+	default:
+		expr, offset = "$."+expr, offset-2
+	}
+	p := &pathParser{src: expr, offset: offset}
+	p.pos = 1
+	segments, err := p.segments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.rest())
+	}
+	return &Path{source: source, segments: segments}, nil
+}
+
+// String 返回原始表达式
+func (p *Path) String() string {
+	return p.source
+}
+
+// Query 在文档中查询，最多返回 MaxValues 个结果，最多访问 MaxQuerySteps 个节点，ctx 结束时返回 ctx 的错误
+func (p *Path) Query(ctx context.Context, root any) ([]Match, error) {
+	e := &evaluator{ctx: ctx, root: root, absolute: map[*queryOperand][]Match{}}
+	return e.evaluate(p.segments, Match{Path: "$", Value: root})
+}
+
+func (e *evaluator) evaluate(segments []segment, start Match) ([]Match, error) {
+	nodes := []Match{start}
+	for _, seg := range segments {
+		var next []Match
+		for _, node := range nodes {
+			targets := []Match{node}
+			if seg.descendant {
+				targets = e.descendants(node, nil)
+			}
+			if !e.step(1) {
+				return nil, e.err
+			}
+			for _, target := range targets {
+				for _, sel := range seg.selectors {
+					next = sel.apply(target, e, next)
+				}
+			}
+			if e.err != nil {
+				return nil, e.err
+			}
+			if len(next) > MaxValues {
+				return nil, fmt.Errorf("the query selects more than %d values", MaxValues)
+			}
+		}
+		nodes = next
+	}
+	return nodes, nil
+}
+
+// descendants 返回节点自身和所有后代，按文档顺序，超出求值步数时提前结束
+func (e *evaluator) descendants(node Match, out []Match) []Match {
+	if !e.visit(node) {
+		return out
+	}
+	out = append(out, node)
+	for _, child := range children(node) {
+		out = e.descendants(child, out)
+	}
+	return out
+}
+
+// children 返回对象的所有值或数组的所有元素
+func children(node Match) []Match {
+	switch v := node.Value.(type) {
+	case *Object:
+		out := make([]Match, 0, v.Len())
+		for _, key := range v.keys {
+			out = append(out, Match{Path: childPath(node.Path, key), Value: v.values[key]})
+		}
+		return out
+	case []any:
+		out := make([]Match, 0, len(v))
+		for i, item := range v {
+			out = append(out, Match{Path: indexPath(node.Path, i), Value: item})
+		}
+		return out
+	}
+	return nil
+}
+
+// childPath 返回对象成员的规范化路径，不是标识符的键使用 ['key'] 形式
+func childPath(path, key string) string {
+	if isIdentifier(key) {
+		return path + "." + key
+	}
+	quoted := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(key)
+	return path + "['" + quoted + "']"
+}
+
+// indexPath 返回数组元素的规范化路径
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !isNameChar(r) || (i == 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNameChar(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r) || r > unicode.MaxASCII
+}
+
+type nameSelector string
+
+func (s nameSelector) apply(node Match, _ *evaluator, out []Match) []Match {
+	if obj, ok := node.Value.(*Object); ok {
+		if v, ok := obj.Get(string(s)); ok {
+			out = append(out, Match{Path: childPath(node.Path, string(s)), Value: v})
+		}
+	}
+	return out
+}
+
+type wildcardSelector struct{}
+
+func (wildcardSelector) apply(node Match, _ *evaluator, out []Match) []Match {
+	return append(out, children(node)...)
+}
+
+type indexSelector int
+
+func (s indexSelector) apply(node Match, _ *evaluator, out []Match) []Match {
+	array, ok := node.Value.([]any)
+	if !ok {
+		return out
+	}
+	i := int(s)
+	if i < 0 {
+		i += len(array)
+	}
+	if i < 0 || i >= len(array) {
+		return out
+	}
+	return append(out, Match{Path: indexPath(node.Path, i), Value: array[i]})
+}
+
+// sliceSelector [start:end:step]，省略的部分为 nil，语义与 Python 的切片相同
+type sliceSelector struct {
+	start, end, step *int
+}
+
+func (s sliceSelector) apply(node Match, _ *evaluator, out []Match) []Match {
+	array, ok := node.Value.([]any)
+	if !ok {
+		return out
+	}
+	n := len(array)
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return out
+	}
+	normalize := func(i *int, def int) int {
+		if i == nil {
+			return def
+		}
+		if *i < 0 {
+			return *i + n
+		}
+		return *i
+	}
+	if step > 0 {
+		start := min(max(normalize(s.start, 0), 0), n)
+		end := min(max(normalize(s.end, n), 0), n)
+		for i := start; i < end; i += step {
+			out = append(out, Match{Path: indexPath(node.Path, i), Value: array[i]})
+		}
+		return out
+	}
+	start := min(max(normalize(s.start, n-1), -1), n-1)
+	end := min(max(normalize(s.end, -n-1), -1), n-1)
+	for i := start; i > end; i += step {
+		out = append(out, Match{Path: indexPath(node.Path, i), Value: array[i]})
+	}
+	return out
+}
+
+// filterSelector [?expr]，对每个子节点求值，@ 指向子节点
+type filterSelector struct {
+	expr filterExpr
+}
+
+func (s filterSelector) apply(node Match, e *evaluator, out []Match) []Match {
+	for _, child := range children(node) {
+		if !e.visit(child) {
+			return out
+		}
+		if s.expr.test(child, e) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// filterExpr 过滤器中的逻辑表达式
+type filterExpr interface {
+	test(current Match, e *evaluator) bool
+}
+
+type orExpr struct{ left, right filterExpr }
+
+func (x orExpr) test(current Match, e *evaluator) bool {
+	return x.left.test(current, e) || x.right.test(current, e)
+}
+
+type andExpr struct{ left, right filterExpr }
+
+func (x andExpr) test(current Match, e *evaluator) bool {
+	return x.left.test(current, e) && x.right.test(current, e)
+}
+
+type notExpr struct{ expr filterExpr }
+
+func (x notExpr) test(current Match, e *evaluator) bool {
+	return !x.expr.test(current, e)
+}
+
+// existsExpr 路径是否选中了至少一个值
+type existsExpr struct{ path *queryOperand }
+
+func (x existsExpr) test(current Match, e *evaluator) bool {
+	return len(x.path.query(current, e)) > 0
+}
+
+// compareExpr 比较两个操作数
+type compareExpr struct {
+	op          string
+	left, right operand
+	pattern     *regexp.Regexp // =~ 的正则表达式
+}
+
+func (x compareExpr) test(current Match, e *evaluator) bool {
+	a, aok := x.left.value(current, e)
+	if x.op == "=~" {
+		s, ok := a.(string)
+		return aok && ok && x.pattern.MatchString(s)
+	}
+	b, bok := x.right.value(current, e)
+	switch x.op {
+	case "==":
+		return equalOperands(a, aok, b, bok)
+	case "!=":
+		return !equalOperands(a, aok, b, bok)
+	case "<":
+		return less(a, aok, b, bok)
+	case "<=":
+		return less(a, aok, b, bok) || equalOperands(a, aok, b, bok)
+	case ">":
+		return less(b, bok, a, aok)
+	case ">=":
+		return less(b, bok, a, aok) || equalOperands(a, aok, b, bok)
+	}
+	return false
+}
+
+// equalOperands 两个操作数都不存在时相等
+func equalOperands(a any, aok bool, b any, bok bool) bool {
+	if !aok || !bok {
+		return aok == bok
+	}
+	return Equal(a, b)
+}
+
+// less 只比较两个数值或两个字符串
+func less(a any, aok bool, b any, bok bool) bool {
+	if !aok || !bok {
+		return false
+	}
+	if cmp, ok := compareNumbers(a, b); ok {
+		return cmp < 0
+	}
+	x, okX := a.(string)
+	y, okY := b.(string)
+	return okX && okY && x < y
+}
+
+// operand 比较的操作数，ok 为 false 表示不存在（路径没有选中恰好一个值）
+type operand interface {
+	value(current Match, e *evaluator) (any, bool)
+}
+
+type literalOperand struct{ v any }
+
+func (o literalOperand) value(Match, *evaluator) (any, bool) {
+	return o.v, true
+}
+
+// queryOperand 以 @ 或 $ 开头的路径
+type queryOperand struct {
+	absolute bool
+	segments []segment
+}
+
+// query 求值子查询，以 $ 开头的子查询只在第一次使用时求值
+func (o *queryOperand) query(current Match, e *evaluator) []Match {
+	if !o.absolute {
+		matches, _ := e.evaluate(o.segments, current)
+		return matches
+	}
+	if matches, ok := e.absolute[o]; ok {
+		return matches
+	}
+	matches, _ := e.evaluate(o.segments, Match{Path: "$", Value: e.root})
+	e.absolute[o] = matches
+	return matches
+}
+
+func (o *queryOperand) value(current Match, e *evaluator) (any, bool) {
+	matches := o.query(current, e)
+	if len(matches) != 1 {
+		return nil, false
+	}
+	return matches[0].Value, true
+}
+
+// lengthOperand length() 函数，返回字符串的字符数、数组的元素数或对象的成员数
+type lengthOperand struct{ arg operand }
+
+func (o lengthOperand) value(current Match, e *evaluator) (any, bool) {
+	v, ok := o.arg.value(current, e)
+	if !ok {
+		return nil, false
+	}
+	var n int
+	switch v := v.(type) {
+	case string:
+		n = utf8.RuneCountInString(v)
+	case []any:
+		n = len(v)
+	case *Object:
+		n = v.Len()
+	default:
+		return nil, false
+	}
+	return json.Number(strconv.Itoa(n)), true
+}
+
+// pathParser JSONPath 的递归下降解析器，pos 为 src 中的字节偏移，offset 用于将位置换算回原始表达式
+type pathParser struct {
+	src    string
+	pos    int
+	offset int
+	depth  int // 括号、! 、length() 和过滤器的嵌套层数
+}
+
+// maxPathNesting 过滤器表达式允许的最大嵌套层数，防止递归下降解析耗尽栈
+const maxPathNesting = 256
+
+// enter 进入一层嵌套，超过 maxPathNesting 时返回错误，调用方需在返回前调用 leave
+func (p *pathParser) enter() error {
+	p.depth++
+	if p.depth > maxPathNesting {
+		return p.errorf("the expression nests more than %d levels", maxPathNesting)
+	}
+	return nil
+}
+
+func (p *pathParser) leave() {
+	p.depth--
+}
+
+func (p *pathParser) errorf(format string, args ...any) error {
+	pos := utf8.RuneCountInString(p.src[:min(p.pos, len(p.src))]) + p.offset + 1
+	return &PathError{Pos: max(pos, 1), Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *pathParser) rest() string {
+	return p.src[p.pos:]
+}
+
+func (p *pathParser) skipSpace() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume 跳过空白后如果下一个记号是 token 则消费它
+func (p *pathParser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.rest(), token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// segments 解析连续的路径段，遇到无法识别的字符时停止
+func (p *pathParser) segments() ([]segment, error) {
+	var segments []segment
+	for p.pos < len(p.src) {
+		switch {
+		case strings.HasPrefix(p.rest(), ".."):
+			p.pos += 2
+			seg := segment{descendant: true}
+			var err error
+			if strings.HasPrefix(p.rest(), "[") {
+				p.pos++
+				seg.selectors, err = p.bracket()
+			} else {
+				seg.selectors, err = p.dotSelector()
+			}
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+		case strings.HasPrefix(p.rest(), "."):
+			p.pos++
+			selectors, err := p.dotSelector()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment{selectors: selectors})
+		case strings.HasPrefix(p.rest(), "["):
+			p.pos++
+			selectors, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment{selectors: selectors})
+		default:
+			return segments, nil
+		}
+	}
+	return segments, nil
+}
+
+// dotSelector 解析 . 或 .. 之后的名称或 *
+func (p *pathParser) dotSelector() ([]selector, error) {
+	if strings.HasPrefix(p.rest(), "*") {
+		p.pos++
+		return []selector{wildcardSelector{}}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRuneInString(p.rest())
+		if !isNameChar(r) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected a member name or * after .")
+	}
+	return []selector{nameSelector(p.src[start:p.pos])}, nil
+}
+
+// bracket 解析 [ 之后以逗号分隔的选择器，直到 ]
+func (p *pathParser) bracket() ([]selector, error) {
+	if p.consume("]") {
+		// jq 风格的 [] 等价于 [*]
+		return []selector{wildcardSelector{}}, nil
+	}
+	var selectors []selector
+	for {
+		sel, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ]")
+		}
+	}
+}
+
+func (p *pathParser) selector() (selector, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of path, expected ]")
+	}
+	switch c := p.src[p.pos]; {
+	case c == '*':
+		p.pos++
+		return wildcardSelector{}, nil
+	case c == '\'' || c == '"':
+		s, err := p.stringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector(s), nil
+	case c == '?':
+		p.pos++
+		expr, err := p.orExpr()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	case c == '-' || c == ':' || (c >= '0' && c <= '9'):
+		return p.indexOrSlice()
+	default:
+		return nil, p.errorf("unexpected %q in brackets", string(c))
+	}
+}
+
+// indexOrSlice 解析索引或 start:end:step 切片
+func (p *pathParser) indexOrSlice() (selector, error) {
+	var parts [3]*int
+	n := 0
+	for {
+		p.skipSpace()
+		if p.pos < len(p.src) && (p.src[p.pos] == '-' || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			start := p.pos
+			p.pos++
+			for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+				p.pos++
+			}
+			i, err := strconv.Atoi(p.src[start:p.pos])
+			if err != nil {
+				p.pos = start
+				return nil, p.errorf("invalid index %q", p.src[start:p.pos])
+			}
+			parts[n] = &i
+		}
+		if n == 2 || !p.consume(":") {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		if parts[0] == nil {
+			return nil, p.errorf("expected an index")
+		}
+		return indexSelector(*parts[0]), nil
+	}
+	return sliceSelector{start: parts[0], end: parts[1], step: parts[2]}, nil
+}
+
+// stringLiteral 解析单引号或双引号字符串，支持 JSON 的转义序列，其他转义原样保留
+func (p *pathParser) stringLiteral() (string, error) {
+	quote := p.src[p.pos]
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return "", p.errorf("unterminated escape sequence")
+			}
+			esc := p.src[p.pos+1]
+			p.pos += 2
+			switch esc {
+			case '\'', '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if p.pos+4 > len(p.src) {
+					return "", p.errorf("invalid \\u escape")
+				}
+				r, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid \\u escape")
+				}
+				b.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				// 保留其他转义，便于在字符串中书写 \d 这样的正则表达式
+				b.WriteByte('\\')
+				b.WriteByte(esc)
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	p.pos = start
+	return "", p.errorf("unterminated string")
+}
+
+func (p *pathParser) orExpr() (filterExpr, error) {
+	left, err := p.andExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("||") {
+		right, err := p.andExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *pathParser) andExpr() (filterExpr, error) {
+	left, err := p.unaryExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&&") {
+		right, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *pathParser) unaryExpr() (filterExpr, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	if p.consume("!") {
+		if strings.HasPrefix(p.rest(), "=") {
+			return nil, p.errorf("unexpected =")
+		}
+		expr, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr}, nil
+	}
+	if p.consume("(") {
+		expr, err := p.orExpr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	}
+	return p.comparison()
+}
+
+// comparisonOps 比较运算符，较长的放在前面
+var comparisonOps = []string{"==", "!=", "<=", ">=", "=~", "<", ">"}
+
+func (p *pathParser) comparison() (filterExpr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	for _, op := range comparisonOps {
+		if !strings.HasPrefix(p.rest(), op) {
+			continue
+		}
+		p.pos += len(op)
+		if op == "=~" {
+			pattern, err := p.regexLiteral()
+			if err != nil {
+				return nil, err
+			}
+			return compareExpr{op: op, left: left, pattern: pattern}, nil
+		}
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		return compareExpr{op: op, left: left, right: right}, nil
+	}
+	query, ok := left.(*queryOperand)
+	if !ok {
+		return nil, p.errorf("expected a comparison operator")
+	}
+	return existsExpr{path: query}, nil
+}
+
+// regexLiteral 解析 =~ 右侧的 /pattern/flags 或字符串形式的正则表达式
+func (p *pathParser) regexLiteral() (*regexp.Regexp, error) {
+	p.skipSpace()
+	start := p.pos
+	var pattern string
+	switch {
+	case strings.HasPrefix(p.rest(), "/"):
+		end := p.pos + 1
+		for end < len(p.src) && p.src[end] != '/' {
+			if p.src[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.src) {
+			return nil, p.errorf("unterminated regular expression")
+		}
+		pattern = p.src[p.pos+1 : end]
+		pattern = strings.ReplaceAll(pattern, `\/`, "/")
+		p.pos = end + 1
+		flags := p.pos
+		for p.pos < len(p.src) && strings.ContainsRune("ims", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		if p.pos > flags {
+			pattern = "(?" + p.src[flags:p.pos] + ")" + pattern
+		}
+	case strings.HasPrefix(p.rest(), "'") || strings.HasPrefix(p.rest(), `"`):
+		s, err := p.stringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		pattern = s
+	default:
+		return nil, p.errorf("expected a regular expression such as /^a/i or '^a'")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("%v", err)
+	}
+	return re, nil
+}
+
+// keywords 过滤器中的字面量关键字
+var keywords = map[string]any{"true": true, "false": false, "null": nil}
+
+// filterNumber 匹配过滤器中的数值字面量
+var filterNumber = regexp.MustCompile(`^-?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][+-]?\d+)?`)
+
+func (p *pathParser) operand() (operand, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of path, expected a value")
+	}
+	switch c := p.src[p.pos]; {
+	case c == '@' || c == '$':
+		p.pos++
+		segments, err := p.segments()
+		if err != nil {
+			return nil, err
+		}
+		return &queryOperand{absolute: c == '$', segments: segments}, nil
+	case c == '\'' || c == '"':
+		s, err := p.stringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return literalOperand{s}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		number := filterNumber.FindString(p.rest())
+		if number == "" {
+			return nil, p.errorf("invalid number")
+		}
+		p.pos += len(number)
+		return literalOperand{json.Number(number)}, nil
+	}
+	for word, v := range keywords {
+		if strings.HasPrefix(p.rest(), word) {
+			p.pos += len(word)
+			return literalOperand{v}, nil
+		}
+	}
+	if strings.HasPrefix(p.rest(), "length") {
+		p.pos += len("length")
+		if !p.consume("(") {
+			return nil, p.errorf("expected ( after length")
+		}
+		arg, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return lengthOperand{arg}, nil
+	}
+	return nil, p.errorf("expected @, $, a string, a number, true, false, null or length()")
+}
@@ -0,0 +1,750 @@
+package document
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxSchemaErrors 校验最多报告的错误数
+const MaxSchemaErrors = 100
+
+// maxSchemaDepth 子模式嵌套的最大深度，防止循环引用的 $ref 无限递归
+const maxSchemaDepth = 256
+
+// MaxSchemaSteps 一次校验最多用子模式校验实例的次数，相同的子模式和实例只计算一次
+const MaxSchemaSteps = 1000000
+
+// SchemaError 一个校验错误，Path 为实例中出错位置的 JSONPath，SchemaPath 为对应关键字在模式中的 JSON Pointer
+type SchemaError struct {
+	Path       string `json:"path"`
+	Keyword    string `json:"keyword"`
+	SchemaPath string `json:"schema_path"`
+	Message    string `json:"message"`
+}
+
+func (e SchemaError) String() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate 用 JSON Schema 校验实例，返回校验错误；模式本身无效时返回 error
+//
+// 支持 draft 2020-12 和 draft-07 的常用关键字：类型、枚举、数值和字符串约束、format、数组和对象约束、
+// allOf/anyOf/oneOf/not、if/then/else、依赖关系，以及指向文档内部的 $ref（#/$defs/x、#/definitions/x 和 $anchor）。
+// unevaluatedProperties、unevaluatedItems 和外部 $ref 不受支持。
+// 校验超过 MaxSchemaSteps 步或 ctx 结束时返回 error。
+func Validate(ctx context.Context, schema, instance any) ([]SchemaError, error) {
+	v := &validator{
+		ctx:      ctx,
+		root:     schema,
+		patterns: make(map[string]*regexp.Regexp),
+		results:  make(map[resultKey][]SchemaError),
+	}
+	errs := v.validate(schema, instance, "$", "#", 0)
+	if v.err != nil {
+		return nil, v.err
+	}
+	if len(errs) > MaxSchemaErrors {
+		errs = errs[:MaxSchemaErrors]
+	}
+	return errs, nil
+}
+
+type validator struct {
+	ctx      context.Context
+	root     any
+	patterns map[string]*regexp.Regexp
+	err      error // 模式本身的错误，或超出步数、ctx 结束的错误
+	steps    int
+	// results 已经校验过的子模式和实例，allOf 等关键字多次引用同一个子模式时不必重复校验
+	results map[resultKey][]SchemaError
+}
+
+// resultKey 一次子模式校验的输入，错误中的位置由 path 和 schemaPath 决定
+type resultKey struct {
+	schema     *Object
+	instance   any
+	path       string
+	schemaPath string
+}
+
+// arrayKey 用首元素的地址和长度标识数组，[]any 不能直接作为 map 的键
+type arrayKey struct {
+	first *any
+	n     int
+}
+
+// instanceKey 返回可以作为 map 键的实例标识，对象和数组按地址，标量按值
+func instanceKey(instance any) any {
+	if array, ok := instance.([]any); ok {
+		if len(array) == 0 {
+			return arrayKey{}
+		}
+		return arrayKey{first: &array[0], n: len(array)}
+	}
+	return instance
+}
+
+// step 记录一次子模式校验，超出 MaxSchemaSteps 或 ctx 结束时记录错误并返回 false
+func (v *validator) step() bool {
+	v.steps++
+	if v.steps > MaxSchemaSteps {
+		v.err = fmt.Errorf("validation needs more than %d steps, simplify the schema", MaxSchemaSteps)
+		return false
+	}
+	// 每 4096 步检查一次 ctx
+	if v.steps&4095 == 0 {
+		if err := v.ctx.Err(); err != nil {
+			v.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// schemaErrorf 记录模式本身的错误，只保留第一个
+func (v *validator) schemaErrorf(schemaPath, format string, args ...any) {
+	if v.err == nil {
+		v.err = fmt.Errorf("invalid schema at %s: %s", schemaPath, fmt.Sprintf(format, args...))
+	}
+}
+
+// validate 校验实例，返回实例的所有错误
+func (v *validator) validate(schema, instance any, path, schemaPath string, depth int) []SchemaError {
+	if v.err != nil {
+		return nil
+	}
+	if depth > maxSchemaDepth {
+		v.schemaErrorf(schemaPath, "subschemas nest more than %d levels, check for a recursive $ref", maxSchemaDepth)
+		return nil
+	}
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return []SchemaError{{Path: path, Keyword: "false", SchemaPath: schemaPath, Message: "no value is allowed here"}}
+		}
+		return nil
+	case *Object:
+		key := resultKey{schema: s, instance: instanceKey(instance), path: path, schemaPath: schemaPath}
+		if errs, ok := v.results[key]; ok {
+			return errs
+		}
+		if !v.step() {
+			return nil
+		}
+		c := &schemaCheck{v: v, schema: s, instance: instance, path: path, schemaPath: schemaPath, depth: depth}
+		c.run()
+		v.results[key] = c.errs
+		return c.errs
+	default:
+		v.schemaErrorf(schemaPath, "a schema must be an object or a boolean, got %s", TypeName(schema))
+		return nil
+	}
+}
+
+// valid 实例是否满足子模式
+func (v *validator) valid(schema, instance any, path, schemaPath string, depth int) bool {
+	return len(v.validate(schema, instance, path, schemaPath, depth)) == 0
+}
+
+// regexp 编译并缓存模式中的正则表达式
+func (v *validator) regexp(pattern, schemaPath string) *regexp.Regexp {
+	if re, ok := v.patterns[pattern]; ok {
+		return re
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		v.schemaErrorf(schemaPath, "pattern %q is not supported by RE2: %v", pattern, err)
+		return nil
+	}
+	v.patterns[pattern] = re
+	return re
+}
+
+// resolve 解析文档内部的 $ref
+func (v *validator) resolve(ref, schemaPath string) any {
+	fragment, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		v.schemaErrorf(schemaPath, "only references within the schema (#/...) are supported, got %q", ref)
+		return nil
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		if target := findAnchor(v.root, fragment); target != nil {
+			return target
+		}
+		v.schemaErrorf(schemaPath, "$anchor %q not found", fragment)
+		return nil
+	}
+	target := v.root
+	if fragment == "" {
+		return target
+	}
+	for _, token := range strings.Split(fragment[1:], "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := target.(type) {
+		case *Object:
+			next, ok := node.Get(token)
+			if !ok {
+				v.schemaErrorf(schemaPath, "$ref %q not found", ref)
+				return nil
+			}
+			target = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				v.schemaErrorf(schemaPath, "$ref %q not found", ref)
+				return nil
+			}
+			target = node[i]
+		default:
+			v.schemaErrorf(schemaPath, "$ref %q not found", ref)
+			return nil
+		}
+	}
+	return target
+}
+
+// findAnchor 查找 $anchor 等于 name 的子模式
+func findAnchor(schema any, name string) any {
+	var found any
+	_ = walk(schema, "$", func(_ string, node any) error {
+		if obj, ok := node.(*Object); ok && found == nil {
+			if anchor, _ := obj.Get("$anchor"); anchor == name {
+				found = obj
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// schemaCheck 校验一个对象形式的模式
+type schemaCheck struct {
+	v          *validator
+	schema     *Object
+	instance   any
+	path       string
+	schemaPath string
+	depth      int
+	errs       []SchemaError
+}
+
+func (c *schemaCheck) fail(keyword, format string, args ...any) {
+	c.errs = append(c.errs, SchemaError{
+		Path:       c.path,
+		Keyword:    keyword,
+		SchemaPath: c.schemaPath + "/" + keyword,
+		Message:    fmt.Sprintf(format, args...),
+	})
+}
+
+// sub 用子模式校验实例的某个部分，错误直接加入结果
+func (c *schemaCheck) sub(schema, instance any, path, schemaPath string) bool {
+	errs := c.v.validate(schema, instance, path, schemaPath, c.depth+1)
+	// 最终只报告 MaxSchemaErrors 个错误，多次引用同一个子模式时错误数会成倍增长
+	room := max(MaxSchemaErrors-len(c.errs), 0)
+	c.errs = append(c.errs, errs[:min(room, len(errs))]...)
+	return len(errs) == 0
+}
+
+// keyword 返回关键字的值
+func (c *schemaCheck) keyword(name string) (any, bool) {
+	return c.schema.Get(name)
+}
+
+// number 读取数值关键字
+func (c *schemaCheck) number(name string) (*big.Rat, bool) {
+	value, ok := c.keyword(name)
+	if !ok {
+		return nil, false
+	}
+	r, ok := rat(value)
+	if !ok {
+		if _, isBool := value.(bool); !isBool {
+			c.v.schemaErrorf(c.schemaPath+"/"+name, "%s must be a number", name)
+		}
+		return nil, false
+	}
+	return r, true
+}
+
+// count 读取非负整数关键字
+func (c *schemaCheck) count(name string) (int, bool) {
+	r, ok := c.number(name)
+	if !ok {
+		return 0, false
+	}
+	if !r.IsInt() || r.Sign() < 0 || !r.Num().IsInt64() {
+		c.v.schemaErrorf(c.schemaPath+"/"+name, "%s must be a non-negative integer", name)
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+// schemas 读取子模式数组关键字
+func (c *schemaCheck) schemas(name string) []any {
+	value, ok := c.keyword(name)
+	if !ok {
+		return nil
+	}
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		c.v.schemaErrorf(c.schemaPath+"/"+name, "%s must be a non-empty array of schemas", name)
+		return nil
+	}
+	return list
+}
+
+func (c *schemaCheck) run() {
+	if ref, ok := c.keyword("$ref"); ok {
+		s, isString := ref.(string)
+		if !isString {
+			c.v.schemaErrorf(c.schemaPath+"/$ref", "$ref must be a string")
+			return
+		}
+		// 引用目标的错误以目标自身的位置报告，避免递归引用时路径不断变长
+		if target := c.v.resolve(s, c.schemaPath+"/$ref"); target != nil {
+			c.sub(target, c.instance, c.path, s)
+		}
+	}
+	c.checkType()
+	c.checkEnum()
+	c.checkCombinators()
+	switch instance := c.instance.(type) {
+	case json.Number, float64:
+		c.checkNumber(instance)
+	case string:
+		c.checkString(instance)
+	case []any:
+		c.checkArray(instance)
+	case *Object:
+		c.checkObject(instance)
+	}
+}
+
+func (c *schemaCheck) checkType() {
+	value, ok := c.keyword("type")
+	if !ok {
+		return
+	}
+	var types []string
+	switch t := value.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				c.v.schemaErrorf(c.schemaPath+"/type", "type must be a string or an array of strings")
+				return
+			}
+			types = append(types, s)
+		}
+	default:
+		c.v.schemaErrorf(c.schemaPath+"/type", "type must be a string or an array of strings")
+		return
+	}
+	actual := TypeName(c.instance)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return
+		}
+	}
+	c.fail("type", "expected %s, got %s", strings.Join(types, " or "), actual)
+}
+
+func (c *schemaCheck) checkEnum() {
+	if value, ok := c.keyword("const"); ok && !Equal(value, c.instance) {
+		c.fail("const", "must be %s", describe(value))
+	}
+	value, ok := c.keyword("enum")
+	if !ok {
+		return
+	}
+	options, ok := value.([]any)
+	if !ok {
+		c.v.schemaErrorf(c.schemaPath+"/enum", "enum must be an array")
+		return
+	}
+	for _, option := range options {
+		if Equal(option, c.instance) {
+			return
+		}
+	}
+	described := make([]string, len(options))
+	for i, option := range options {
+		described[i] = describe(option)
+	}
+	c.fail("enum", "must be one of %s", strings.Join(described, ", "))
+}
+
+func (c *schemaCheck) checkCombinators() {
+	for i, schema := range c.schemas("allOf") {
+		c.sub(schema, c.instance, c.path, fmt.Sprintf("%s/allOf/%d", c.schemaPath, i))
+	}
+	if anyOf := c.schemas("anyOf"); anyOf != nil {
+		var reasons []string
+		for i, schema := range anyOf {
+			errs := c.v.validate(schema, c.instance, c.path, fmt.Sprintf("%s/anyOf/%d", c.schemaPath, i), c.depth+1)
+			if len(errs) == 0 {
+				reasons = nil
+				break
+			}
+			reasons = append(reasons, fmt.Sprintf("[%d] %s", i, errs[0]))
+		}
+		if reasons != nil {
+			c.fail("anyOf", "does not match any schema: %s", strings.Join(reasons, "; "))
+		}
+	}
+	if oneOf := c.schemas("oneOf"); oneOf != nil {
+		var matched []string
+		var reasons []string
+		for i, schema := range oneOf {
+			errs := c.v.validate(schema, c.instance, c.path, fmt.Sprintf("%s/oneOf/%d", c.schemaPath, i), c.depth+1)
+			if len(errs) == 0 {
+				matched = append(matched, strconv.Itoa(i))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("[%d] %s", i, errs[0]))
+			}
+		}
+		switch {
+		case len(matched) == 0:
+			c.fail("oneOf", "does not match any schema: %s", strings.Join(reasons, "; "))
+		case len(matched) > 1:
+			c.fail("oneOf", "matches more than one schema (%s)", strings.Join(matched, ", "))
+		}
+	}
+	if not, ok := c.keyword("not"); ok && c.v.valid(not, c.instance, c.path, c.schemaPath+"/not", c.depth+1) {
+		c.fail("not", "must not match the schema in not")
+	}
+	if cond, ok := c.keyword("if"); ok {
+		if c.v.valid(cond, c.instance, c.path, c.schemaPath+"/if", c.depth+1) {
+			if then, ok := c.keyword("then"); ok {
+				c.sub(then, c.instance, c.path, c.schemaPath+"/then")
+			}
+		} else if otherwise, ok := c.keyword("else"); ok {
+			c.sub(otherwise, c.instance, c.path, c.schemaPath+"/else")
+		}
+	}
+}
+
+func (c *schemaCheck) checkNumber(instance any) {
+	n, ok := rat(instance)
+	if !ok {
+		c.fail("type", "%v is not a finite number", instance)
+		return
+	}
+	// draft-04 中 exclusiveMinimum 和 exclusiveMaximum 是修饰 minimum 和 maximum 的布尔值
+	exclusiveMin, _ := c.keyword("exclusiveMinimum")
+	exclusiveMax, _ := c.keyword("exclusiveMaximum")
+	if limit, ok := c.number("minimum"); ok {
+		if exclusiveMin == true && n.Cmp(limit) <= 0 {
+			c.fail("minimum", "must be greater than %s", ratString(limit))
+		} else if n.Cmp(limit) < 0 {
+			c.fail("minimum", "must be at least %s", ratString(limit))
+		}
+	}
+	if limit, ok := c.number("maximum"); ok {
+		if exclusiveMax == true && n.Cmp(limit) >= 0 {
+			c.fail("maximum", "must be less than %s", ratString(limit))
+		} else if n.Cmp(limit) > 0 {
+			c.fail("maximum", "must be at most %s", ratString(limit))
+		}
+	}
+	if limit, ok := c.number("exclusiveMinimum"); ok && n.Cmp(limit) <= 0 {
+		c.fail("exclusiveMinimum", "must be greater than %s", ratString(limit))
+	}
+	if limit, ok := c.number("exclusiveMaximum"); ok && n.Cmp(limit) >= 0 {
+		c.fail("exclusiveMaximum", "must be less than %s", ratString(limit))
+	}
+	if divisor, ok := c.number("multipleOf"); ok {
+		if divisor.Sign() <= 0 {
+			c.v.schemaErrorf(c.schemaPath+"/multipleOf", "multipleOf must be greater than 0")
+		} else if !new(big.Rat).Quo(n, divisor).IsInt() {
+			c.fail("multipleOf", "must be a multiple of %s", ratString(divisor))
+		}
+	}
+}
+
+func (c *schemaCheck) checkString(instance string) {
+	length := utf8.RuneCountInString(instance)
+	if limit, ok := c.count("minLength"); ok && length < limit {
+		c.fail("minLength", "must be at least %d characters, got %d", limit, length)
+	}
+	if limit, ok := c.count("maxLength"); ok && length > limit {
+		c.fail("maxLength", "must be at most %d characters, got %d", limit, length)
+	}
+	if value, ok := c.keyword("pattern"); ok {
+		pattern, isString := value.(string)
+		if !isString {
+			c.v.schemaErrorf(c.schemaPath+"/pattern", "pattern must be a string")
+		} else if re := c.v.regexp(pattern, c.schemaPath+"/pattern"); re != nil && !re.MatchString(instance) {
+			c.fail("pattern", "must match the pattern %s", pattern)
+		}
+	}
+	if value, ok := c.keyword("format"); ok {
+		if format, isString := value.(string); isString {
+			if check, known := formats[format]; known && !check(instance) {
+				c.fail("format", "is not a valid %s", format)
+			}
+		}
+	}
+}
+
+func (c *schemaCheck) checkArray(instance []any) {
+	if limit, ok := c.count("minItems"); ok && len(instance) < limit {
+		c.fail("minItems", "must have at least %d items, got %d", limit, len(instance))
+	}
+	if limit, ok := c.count("maxItems"); ok && len(instance) > limit {
+		c.fail("maxItems", "must have at most %d items, got %d", limit, len(instance))
+	}
+	if unique, _ := c.keyword("uniqueItems"); unique == true {
+	outer:
+		for i := range instance {
+			for j := i + 1; j < len(instance); j++ {
+				if Equal(instance[i], instance[j]) {
+					c.fail("uniqueItems", "items %d and %d are equal", i, j)
+					break outer
+				}
+			}
+		}
+	}
+
+	// prefixItems（2020-12）或数组形式的 items（draft-07）按位置校验，其余元素由 items 或 additionalItems 校验
+	prefix, rest, restKeyword := c.schemas("prefixItems"), any(nil), "items"
+	items, hasItems := c.keyword("items")
+	if tuple, ok := items.([]any); ok && hasItems {
+		prefix = tuple
+		rest, _ = c.keyword("additionalItems")
+		restKeyword = "additionalItems"
+	} else if hasItems {
+		rest = items
+	}
+	for i, item := range instance {
+		if i < len(prefix) {
+			keyword := "prefixItems"
+			if restKeyword == "additionalItems" {
+				keyword = "items"
+			}
+			c.sub(prefix[i], item, indexPath(c.path, i), fmt.Sprintf("%s/%s/%d", c.schemaPath, keyword, i))
+		} else if rest != nil {
+			c.sub(rest, item, indexPath(c.path, i), c.schemaPath+"/"+restKeyword)
+		}
+	}
+
+	if contains, ok := c.keyword("contains"); ok {
+		matches := 0
+		for i, item := range instance {
+			if c.v.valid(contains, item, indexPath(c.path, i), c.schemaPath+"/contains", c.depth+1) {
+				matches++
+			}
+		}
+		minimum, hasMin := c.count("minContains")
+		if !hasMin {
+			minimum = 1
+		}
+		if matches < minimum {
+			c.fail("contains", "must contain at least %d matching items, got %d", minimum, matches)
+		}
+		if maximum, ok := c.count("maxContains"); ok && matches > maximum {
+			c.fail("maxContains", "must contain at most %d matching items, got %d", maximum, matches)
+		}
+	}
+}
+
+func (c *schemaCheck) checkObject(instance *Object) {
+	if limit, ok := c.count("minProperties"); ok && instance.Len() < limit {
+		c.fail("minProperties", "must have at least %d properties, got %d", limit, instance.Len())
+	}
+	if limit, ok := c.count("maxProperties"); ok && instance.Len() > limit {
+		c.fail("maxProperties", "must have at most %d properties, got %d", limit, instance.Len())
+	}
+	if value, ok := c.keyword("required"); ok {
+		names, isList := value.([]any)
+		if !isList {
+			c.v.schemaErrorf(c.schemaPath+"/required", "required must be an array of strings")
+		}
+		for _, name := range names {
+			if s, _ := name.(string); s != "" {
+				if _, exists := instance.Get(s); !exists {
+					c.fail("required", "missing required property %q", s)
+				}
+			}
+		}
+	}
+
+	properties, _ := c.keyword("properties")
+	propertySchemas, _ := properties.(*Object)
+	patternProperties, _ := c.keyword("patternProperties")
+	patternSchemas, _ := patternProperties.(*Object)
+	additional, hasAdditional := c.keyword("additionalProperties")
+	propertyNames, hasPropertyNames := c.keyword("propertyNames")
+
+	for _, name := range instance.Keys() {
+		value, _ := instance.Get(name)
+		path := childPath(c.path, name)
+		matched := false
+		if propertySchemas != nil {
+			if schema, ok := propertySchemas.Get(name); ok {
+				matched = true
+				c.sub(schema, value, path, c.schemaPath+"/properties/"+pointerEscape(name))
+			}
+		}
+		if patternSchemas != nil {
+			for _, pattern := range patternSchemas.Keys() {
+				re := c.v.regexp(pattern, c.schemaPath+"/patternProperties")
+				if re != nil && re.MatchString(name) {
+					matched = true
+					schema, _ := patternSchemas.Get(pattern)
+					c.sub(schema, value, path, c.schemaPath+"/patternProperties/"+pointerEscape(pattern))
+				}
+			}
+		}
+		if !matched && hasAdditional {
+			if additional == false {
+				c.errs = append(c.errs, SchemaError{
+					Path:       path,
+					Keyword:    "additionalProperties",
+					SchemaPath: c.schemaPath + "/additionalProperties",
+					Message:    fmt.Sprintf("property %q is not allowed", name),
+				})
+			} else {
+				c.sub(additional, value, path, c.schemaPath+"/additionalProperties")
+			}
+		}
+		if hasPropertyNames {
+			c.sub(propertyNames, name, path, c.schemaPath+"/propertyNames")
+		}
+	}
+
+	c.checkDependencies(instance)
+}
+
+// checkDependencies 处理 dependentRequired、dependentSchemas 和 draft-07 的 dependencies
+func (c *schemaCheck) checkDependencies(instance *Object) {
+	for _, keyword := range []string{"dependentRequired", "dependentSchemas", "dependencies"} {
+		value, ok := c.keyword(keyword)
+		if !ok {
+			continue
+		}
+		deps, isObject := value.(*Object)
+		if !isObject {
+			c.v.schemaErrorf(c.schemaPath+"/"+keyword, "%s must be an object", keyword)
+			continue
+		}
+		for _, name := range deps.Keys() {
+			if _, present := instance.Get(name); !present {
+				continue
+			}
+			dep, _ := deps.Get(name)
+			if names, isList := dep.([]any); isList {
+				for _, required := range names {
+					if s, _ := required.(string); s != "" {
+						if _, exists := instance.Get(s); !exists {
+							c.fail(keyword, "property %q is required when %q is present", s, name)
+						}
+					}
+				}
+				continue
+			}
+			c.sub(dep, instance, c.path, c.schemaPath+"/"+keyword+"/"+pointerEscape(name))
+		}
+	}
+}
+
+// pointerEscape 转义 JSON Pointer 中的 ~ 和 /
+func pointerEscape(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// describe 返回值的简短 JSON 表示，用于错误信息
+func describe(v any) string {
+	s, err := Encode(v, JSON, 0)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(s) > 60 {
+		return s[:57] + "..."
+	}
+	return s
+}
+
+// ratString 返回有理数的十进制表示
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	f, _ := r.Float64()
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+var (
+	hostnamePattern = regexp.MustCompile(`^(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?)(?:\.(?i:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?))*$`)
+	uuidPattern     = regexp.MustCompile(`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// formats format 关键字支持的格式，未知的格式不做校验
+var formats = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(s))
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, "2000-01-01T"+strings.ToUpper(s))
+		return err == nil
+	},
+	"email": func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"hostname": func(s string) bool {
+		return len(s) <= 253 && hostnamePattern.MatchString(s)
+	},
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"uri-reference": func(s string) bool {
+		_, err := url.Parse(s)
+		return err == nil
+	},
+	"uuid": uuidPattern.MatchString,
+	"regex": func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	},
+}
+
+// FormatNames 返回 format 关键字支持的格式名称
+func FormatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
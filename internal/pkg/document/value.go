@@ -0,0 +1,202 @@
+// Package document JSON、YAML 和 TOML 文档的解析、转换、JSONPath 查询和 JSON Schema 校验
+//
+// 文档统一解析为 JSON 数据模型：*Object（保持键的顺序）、[]any、string、json.Number、bool 和 nil。
+// YAML 和 TOML 中的无穷大和 NaN 以 float64 表示，输出为 JSON 时会报错。
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// Object 保持键顺序的 JSON 对象
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewObject 创建空对象
+func NewObject() *Object {
+	return &Object{values: make(map[string]any)}
+}
+
+// Keys 按插入顺序返回所有键
+func (o *Object) Keys() []string {
+	return o.keys
+}
+
+// Len 返回键的数量
+func (o *Object) Len() int {
+	return len(o.keys)
+}
+
+// Get 返回键对应的值
+func (o *Object) Get(key string) (any, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+// Set 设置键的值，已有的键保持原来的位置
+func (o *Object) Set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// SortKeys 递归地按字典序排列对象的键
+func SortKeys(v any) {
+	switch v := v.(type) {
+	case *Object:
+		sort.Strings(v.keys)
+		for _, value := range v.values {
+			SortKeys(value)
+		}
+	case []any:
+		for _, item := range v {
+			SortKeys(item)
+		}
+	}
+}
+
+// MarshalJSON 按键的顺序输出对象
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := encodeJSON(&buf, key); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := encodeJSON(&buf, o.values[key]); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// encodeJSON 以不转义 HTML 字符的方式编码值
+func encodeJSON(buf *bytes.Buffer, v any) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	// Encoder 会在末尾添加换行
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+// TypeName 返回值的 JSON 类型名称：object、array、string、integer、number、boolean 或 null
+func TypeName(v any) string {
+	switch v := v.(type) {
+	case *Object:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		if r, ok := rat(v); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// rat 将数值转换为有理数，无穷大和 NaN 返回 false
+func rat(v any) (*big.Rat, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		return new(big.Rat).SetString(string(v))
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(v), true
+	}
+	return nil, false
+}
+
+// isNumber 值是否为数值
+func isNumber(v any) bool {
+	switch v.(type) {
+	case json.Number, float64:
+		return true
+	}
+	return false
+}
+
+// compareNumbers 比较两个数值，任一个不是有限数值时 ok 为 false
+func compareNumbers(a, b any) (cmp int, ok bool) {
+	x, okX := rat(a)
+	y, okY := rat(b)
+	if !okX || !okY {
+		return 0, false
+	}
+	return x.Cmp(y), true
+}
+
+// floatNumber 将 float64 转换为文档中的数值，无穷大和 NaN 保持为 float64
+func floatNumber(f float64) any {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return f
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// Equal 按 JSON 语义比较两个值，数值按大小比较（1 和 1.0 相等），对象不考虑键的顺序
+func Equal(a, b any) bool {
+	switch a := a.(type) {
+	case *Object:
+		b, ok := b.(*Object)
+		if !ok || a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.keys {
+			other, ok := b.values[key]
+			if !ok || !Equal(a.values[key], other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !Equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number, float64:
+		if cmp, ok := compareNumbers(a, b); ok {
+			return cmp == 0
+		}
+		// 无穷大只与自身相等
+		x, okX := a.(float64)
+		y, okY := b.(float64)
+		return okX && okY && x == y
+	default:
+		return a == b
+	}
+}
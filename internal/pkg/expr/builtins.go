@@ -0,0 +1,125 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// constants 内置常量
+var constants = map[string]float64{
+	"pi":  math.Pi,
+	"e":   math.E,
+	"tau": 2 * math.Pi,
+	"phi": math.Phi,
+}
+
+// angleMode 三角函数的参数或结果是否是角度
+type angleMode int
+
+const (
+	angleNone   angleMode = iota
+	angleInput            // 第一个参数是角度，例如 sin
+	angleOutput           // 结果是角度，例如 asin
+)
+
+// function 内置函数，maxArgs 为 -1 时参数个数不限
+type function struct {
+	minArgs int
+	maxArgs int
+	angle   angleMode
+	call    func(args []float64) float64
+}
+
+func (f function) arity() string {
+	switch {
+	case f.maxArgs < 0:
+		return fmt.Sprintf("at least %d arguments", f.minArgs)
+	case f.minArgs == f.maxArgs && f.minArgs == 1:
+		return "1 argument"
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d arguments", f.minArgs)
+	default:
+		return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+	}
+}
+
+func unary(fn func(float64) float64, angle angleMode) function {
+	return function{minArgs: 1, maxArgs: 1, angle: angle, call: func(args []float64) float64 {
+		return fn(args[0])
+	}}
+}
+
+func binary(fn func(float64, float64) float64, angle angleMode) function {
+	return function{minArgs: 2, maxArgs: 2, angle: angle, call: func(args []float64) float64 {
+		return fn(args[0], args[1])
+	}}
+}
+
+// functions 内置函数
+var functions = map[string]function{
+	"sqrt":  unary(math.Sqrt, angleNone),
+	"cbrt":  unary(math.Cbrt, angleNone),
+	"abs":   unary(math.Abs, angleNone),
+	"exp":   unary(math.Exp, angleNone),
+	"ln":    unary(math.Log, angleNone),
+	"log2":  unary(math.Log2, angleNone),
+	"log10": unary(math.Log10, angleNone),
+	"floor": unary(math.Floor, angleNone),
+	"ceil":  unary(math.Ceil, angleNone),
+	"round": unary(math.Round, angleNone),
+	"trunc": unary(math.Trunc, angleNone),
+	"sin":   unary(math.Sin, angleInput),
+	"cos":   unary(math.Cos, angleInput),
+	"tan":   unary(math.Tan, angleInput),
+	"asin":  unary(math.Asin, angleOutput),
+	"acos":  unary(math.Acos, angleOutput),
+	"atan":  unary(math.Atan, angleOutput),
+	"sinh":  unary(math.Sinh, angleNone),
+	"cosh":  unary(math.Cosh, angleNone),
+	"tanh":  unary(math.Tanh, angleNone),
+	"pow":   binary(math.Pow, angleNone),
+	"atan2": binary(math.Atan2, angleOutput),
+	"hypot": binary(math.Hypot, angleNone),
+	// log(x) 为自然对数，log(x, base) 为指定底数的对数
+	"log": {minArgs: 1, maxArgs: 2, call: func(args []float64) float64 {
+		if len(args) == 2 {
+			return math.Log(args[0]) / math.Log(args[1])
+		}
+		return math.Log(args[0])
+	}},
+	"min": {minArgs: 1, maxArgs: -1, call: func(args []float64) float64 {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result
+	}},
+	"max": {minArgs: 1, maxArgs: -1, call: func(args []float64) float64 {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result
+	}},
+}
+
+// Functions 返回所有内置函数名，按字母排序
+func Functions() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Constants 返回所有内置常量名，按字母排序
+func Constants() []string {
+	names := make([]string, 0, len(constants))
+	for name := range constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
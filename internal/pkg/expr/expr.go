@@ -0,0 +1,210 @@
+package expr
+
+import (
+	"math"
+	"slices"
+)
+
+// Expr 解析后的表达式，可以使用不同的变量多次求值
+type Expr struct {
+	root node
+}
+
+// Env 求值时的变量和选项
+type Env struct {
+	Vars    map[string]float64
+	Degrees bool // 三角函数使用角度而不是弧度
+}
+
+// Parse 解析表达式，支持 + - * / % ^ 运算、括号、变量、常量和函数调用
+func Parse(src string) (*Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(1, "empty expression")
+	}
+	root, err := p.parseExpr(1)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errorf(t.pos, "unexpected %s", t)
+	}
+	return &Expr{root: root}, nil
+}
+
+// Eval 求值，结果不是有限实数时返回错误
+func (e *Expr) Eval(env Env) (float64, error) {
+	return e.root.eval(&env)
+}
+
+// Variables 返回表达式中引用的变量名，不包括常量
+func (e *Expr) Variables() []string {
+	var names []string
+	walk(e.root, func(n node) {
+		if ident, ok := n.(*identNode); ok {
+			if _, isConst := constants[ident.name]; !isConst && !slices.Contains(names, ident.name) {
+				names = append(names, ident.name)
+			}
+		}
+	})
+	return names
+}
+
+// IsReserved 判断名称是否是内置常量或函数，不能用作变量名
+func IsReserved(name string) bool {
+	_, isConst := constants[name]
+	_, isFunc := functions[name]
+	return isConst || isFunc
+}
+
+type node interface {
+	eval(env *Env) (float64, error)
+}
+
+type numberNode struct {
+	value float64
+	pos   int
+}
+
+type identNode struct {
+	name string
+	pos  int
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+	pos     int
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+	pos         int
+}
+
+type callNode struct {
+	name string
+	args []node
+	pos  int
+}
+
+func walk(n node, fn func(node)) {
+	fn(n)
+	switch n := n.(type) {
+	case *unaryNode:
+		walk(n.operand, fn)
+	case *binaryNode:
+		walk(n.left, fn)
+		walk(n.right, fn)
+	case *callNode:
+		for _, arg := range n.args {
+			walk(arg, fn)
+		}
+	}
+}
+
+func (n *numberNode) eval(_ *Env) (float64, error) {
+	return n.value, nil
+}
+
+func (n *identNode) eval(env *Env) (float64, error) {
+	if value, ok := constants[n.name]; ok {
+		return value, nil
+	}
+	if value, ok := env.Vars[n.name]; ok {
+		return value, nil
+	}
+	if _, ok := functions[n.name]; ok {
+		return 0, errorf(n.pos, "function %s must be called with arguments", n.name)
+	}
+	return 0, errorf(n.pos, "undefined variable %s", n.name)
+}
+
+func (n *unaryNode) eval(env *Env) (float64, error) {
+	value, err := n.operand.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	if n.op == "-" {
+		return -value, nil
+	}
+	return value, nil
+}
+
+func (n *binaryNode) eval(env *Env) (float64, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return 0, err
+	}
+
+	var result float64
+	switch n.op {
+	case "+":
+		result = left + right
+	case "-":
+		result = left - right
+	case "*":
+		result = left * right
+	case "/":
+		if right == 0 {
+			return 0, errorf(n.pos, "division by zero")
+		}
+		result = left / right
+	case "%":
+		if right == 0 {
+			return 0, errorf(n.pos, "modulo by zero")
+		}
+		result = math.Mod(left, right)
+	case "^":
+		result = math.Pow(left, right)
+	}
+	return checkResult(n.pos, n.op, result)
+}
+
+func (n *callNode) eval(env *Env) (float64, error) {
+	fn, ok := functions[n.name]
+	if !ok {
+		return 0, errorf(n.pos, "unknown function %s", n.name)
+	}
+	if len(n.args) < fn.minArgs || (fn.maxArgs >= 0 && len(n.args) > fn.maxArgs) {
+		return 0, errorf(n.pos, "%s expects %s, got %d", n.name, fn.arity(), len(n.args))
+	}
+
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(env)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = value
+	}
+	if env.Degrees && fn.angle == angleInput {
+		args[0] = args[0] * math.Pi / 180
+	}
+
+	result := fn.call(args)
+	if env.Degrees && fn.angle == angleOutput {
+		result = result * 180 / math.Pi
+	}
+	return checkResult(n.pos, n.name, result)
+}
+
+// checkResult 拒绝 NaN 和无穷大，例如 sqrt(-1)、log(0) 或溢出
+func checkResult(pos int, op string, result float64) (float64, error) {
+	switch {
+	case math.IsNaN(result):
+		return 0, errorf(pos, "%s: result is not a real number", op)
+	case math.IsInf(result, 0):
+		return 0, errorf(pos, "%s: result is infinite", op)
+	}
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvalPrecedence(t *testing.T) {
+	tests := []struct {
+		src  string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"100 / 10 / 5", 2},
+		{"7 % 4 * 2", 6},
+		{"2 ^ 3 ^ 2", 512},
+		{"2 ** 3", 8},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"2 ^ -1", 0.5},
+		{"--3", 3},
+		{"-+-3", 3},
+		{"1.5e2 + .5", 150.5},
+		{"2 * pi", 2 * math.Pi},
+		{"sqrt(16) + abs(-2)", 6},
+		{"log(100, 10)", 2},
+		{"max(1, 5, 3)", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.src, err)
+			}
+			got, err := e.Eval(Env{})
+			if err != nil {
+				t.Fatalf("Eval(%q) error: %v", tt.src, err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Eval(%q) = %v, want %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalVariables(t *testing.T) {
+	e, err := Parse("2 * pi * r ^ 2 + r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars := e.Variables(); len(vars) != 1 || vars[0] != "r" {
+		t.Errorf("Variables() = %v, want [r]", vars)
+	}
+	got, err := e.Eval(Env{Vars: map[string]float64{"r": 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 2*math.Pi + 1; got != want {
+		t.Errorf("Eval = %v, want %v", got, want)
+	}
+
+	deg, err := Parse("sin(90)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := deg.Eval(Env{Degrees: true}); err != nil || math.Abs(got-1) > 1e-12 {
+		t.Errorf("sin(90) in degrees = %v, %v, want 1", got, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+		msg string
+	}{
+		{"", 1, "empty expression"},
+		{"1 +", 4, "unexpected end of expression"},
+		{"(1 + 2", 7, "expected ) to close ( at position 1"},
+		{"1 2", 3, `unexpected "2"`},
+		{"2 $ 3", 3, "unexpected character"},
+		{"max(1, 2", 9, "expected , or ) in call to max"},
+		{"1..2", 1, "invalid number"},
+		{strings.Repeat("(", MaxNesting+1) + "1" + strings.Repeat(")", MaxNesting+1), MaxNesting + 1, "nests more than"},
+		{strings.Repeat("-", MaxNesting+1) + "1", MaxNesting + 1, "nests more than"},
+		{strings.Repeat("2^", MaxNesting+1) + "2", 2*MaxNesting + 1, "nests more than"},
+	}
+	for _, tt := range tests {
+		name := tt.src
+		if len(name) > 20 {
+			name = name[:20] + "..."
+		}
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			var exprErr *Error
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("Parse(%q) error = %v, want *Error", tt.src, err)
+			}
+			if exprErr.Pos != tt.pos || !strings.Contains(exprErr.Msg, tt.msg) {
+				t.Errorf("Parse(%q) error = %d: %s, want %d: ...%s...", tt.src, exprErr.Pos, exprErr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+}
+
+func TestParseNestingWithinLimit(t *testing.T) {
+	src := strings.Repeat("(", MaxNesting-1) + "1" + strings.Repeat(")", MaxNesting-1)
+	if _, err := Parse(src); err != nil {
+		t.Errorf("Parse with %d levels of parentheses: %v", MaxNesting-1, err)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+		msg string
+	}{
+		{"1 / 0", 3, "division by zero"},
+		{"5 % 0", 3, "modulo by zero"},
+		{"sqrt(-1)", 1, "not a real number"},
+		{"10 ^ 400", 4, "infinite"},
+		{"x + 1", 1, "undefined variable x"},
+		{"sqrt + 1", 1, "must be called with arguments"},
+		{"nope(1)", 1, "unknown function nope"},
+		{"sqrt(1, 2)", 1, "expects 1 argument"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.src, err)
+			}
+			_, err = e.Eval(Env{})
+			var exprErr *Error
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("Eval(%q) error = %v, want *Error", tt.src, err)
+			}
+			if exprErr.Pos != tt.pos || !strings.Contains(exprErr.Msg, tt.msg) {
+				t.Errorf("Eval(%q) error = %d: %s, want %d: ...%s...", tt.src, exprErr.Pos, exprErr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+}
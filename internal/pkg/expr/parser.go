@@ -0,0 +1,242 @@
+// Package expr 算术表达式的解析和求值
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Error 表达式的解析或求值错误，Pos 为出错字符的位置，从 1 开始
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+func errorf(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator // + - * / % ^
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex 将表达式拆分为 token
+func lex(src string) ([]token, error) {
+	runes := []rune(src)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// 科学计数法，例如 1.5e-3
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					for j < len(runes) && unicode.IsDigit(runes[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+			text := string(runes[start:i])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, errorf(pos, "invalid number %q", text)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: value, pos: pos})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: pos})
+		case strings.ContainsRune("+-*/%^", r):
+			// ** 等同于 ^
+			if r == '*' && i+1 < len(runes) && runes[i+1] == '*' {
+				tokens = append(tokens, token{kind: tokenOperator, text: "^", pos: pos})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: string(r), pos: pos})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+		default:
+			return nil, errorf(pos, "unexpected character %q", r)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+// 二元运算符的优先级，^ 为右结合
+var precedence = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"%": 2,
+	"^": 4,
+}
+
+// 一元正负号的优先级低于 ^，-2^2 = -4
+const unaryPrecedence = 3
+
+// MaxNesting 括号、一元运算符、^ 和函数调用允许的最大嵌套层数，防止递归下降解析耗尽栈
+const MaxNesting = 256
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseExpr 按优先级解析表达式，minPrec 为当前允许的最低优先级
+func (p *parser) parseExpr(minPrec int) (node, error) {
+	// 所有递归都经过 parseExpr，在这里统计嵌套层数
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > MaxNesting {
+		return nil, errorf(p.peek().pos, "the expression nests more than %d levels", MaxNesting)
+	}
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenOperator {
+			return left, nil
+		}
+		prec := precedence[t.text]
+		if prec < minPrec {
+			return left, nil
+		}
+		p.next()
+		nextPrec := prec + 1
+		if t.text == "^" {
+			nextPrec = prec
+		}
+		right, err := p.parseExpr(nextPrec)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: t.text, left: left, right: right, pos: t.pos}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t.kind == tokenOperator && (t.text == "-" || t.text == "+") {
+		p.next()
+		operand, err := p.parseExpr(unaryPrecedence)
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: t.text, operand: operand, pos: t.pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return &numberNode{value: t.value, pos: t.pos}, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(t)
+		}
+		return &identNode{name: t.text, pos: t.pos}, nil
+	case tokenLParen:
+		inner, err := p.parseExpr(1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, errorf(closing.pos, "expected ) to close ( at position %d, got %s", t.pos, closing)
+		}
+		return inner, nil
+	default:
+		return nil, errorf(t.pos, "unexpected %s", t)
+	}
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	open := p.next()
+	call := &callNode{name: name.text, pos: name.pos}
+	if p.peek().kind == tokenRParen {
+		p.next()
+		return call, nil
+	}
+	for {
+		arg, err := p.parseExpr(1)
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		switch t := p.next(); t.kind {
+		case tokenComma:
+		case tokenRParen:
+			return call, nil
+		default:
+			return nil, errorf(t.pos, "expected , or ) in call to %s at position %d, got %s", name.text, open.pos, t)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package mcpclient 连接远程 MCP 服务器的客户端封装
+package mcpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mcp-go-tutorials/pkg/version"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 支持的传输方式，与服务端的 mode 取值保持一致
+const (
+	TransportStdio = "stdio"
+	TransportSSE   = "sse"
+	TransportHTTP  = "streamableHttp"
+)
+
+// Options 客户端连接选项
+type Options struct {
+	Transport string            // stdio, sse, streamableHttp
+	URL       string            // sse/streamableHttp 模式下的服务地址
+	Command   string            // stdio 模式下启动的命令，包含参数，按 shell 规则处理引号和反斜杠
+	Env       []string          // stdio 模式下子进程的额外环境变量
+	Headers   map[string]string // HTTP 请求头
+	Timeout   time.Duration     // 单个请求的超时时间
+}
+
+// Client 已完成 initialize 握手的 MCP 客户端
+type Client struct {
+	*client.Client
+	opts       Options
+	ServerInfo *mcp.InitializeResult
+}
+
+// Validate 校验连接选项，未指定传输方式时根据 Command/URL 推断
+func (o *Options) Validate() error {
+	if o.Transport == "" {
+		if o.Command != "" {
+			o.Transport = TransportStdio
+		} else {
+			o.Transport = TransportHTTP
+		}
+	}
+
+	switch o.Transport {
+	case TransportStdio:
+		if strings.TrimSpace(o.Command) == "" {
+			return errors.New("--command is required for stdio transport")
+		}
+		if _, err := SplitCommand(o.Command); err != nil {
+			return fmt.Errorf("invalid --command: %w", err)
+		}
+	case TransportSSE, TransportHTTP:
+		if o.URL == "" {
+			return fmt.Errorf("--url is required for %s transport", o.Transport)
+		}
+	default:
+		return fmt.Errorf("unknown transport: %s", o.Transport)
+	}
+	return nil
+}
+
+// Connect 按选项建立连接并完成 initialize 握手
+func Connect(ctx context.Context, opts Options) (*Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	c, err := newClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Start(ctx); err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("start %s transport: %w", opts.Transport, err)
+	}
+
+	initCtx, cancel := opts.requestContext(ctx)
+	defer cancel()
+
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{
+		Name:    "mcp-server-client",
+		Version: version.Get().GitVersion,
+	}
+	result, err := c.Initialize(initCtx, request)
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("initialize: %w", err)
+	}
+
+	return &Client{Client: c, opts: opts, ServerInfo: result}, nil
+}
+
+func newClient(opts Options) (*client.Client, error) {
+	switch opts.Transport {
+	case TransportStdio:
+		fields, err := SplitCommand(opts.Command)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			return nil, errors.New("the command is empty")
+		}
+		c, err := client.NewStdioMCPClient(fields[0], append(os.Environ(), opts.Env...), fields[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		// 将子进程的 stderr 转发到当前进程，便于排查问题
+		if stderr, ok := client.GetStderr(c); ok {
+			go func() { _, _ = io.Copy(os.Stderr, stderr) }()
+		}
+		return c, nil
+	case TransportSSE:
+		return client.NewSSEMCPClient(opts.URL, transport.WithHeaders(opts.Headers))
+	default:
+		return client.NewStreamableHttpClient(opts.URL, transport.WithHTTPHeaders(opts.Headers))
+	}
+}
+
+// SplitCommand 按 shell 的规则将命令行拆分为参数，支持单引号、双引号和反斜杠转义，
+// 例如 "/opt/my tools/server" --name 'a b' 拆分为 3 个参数；不展开变量和通配符。
+// 空白的命令行返回空切片，由调用方决定是否允许
+func SplitCommand(command string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool // current 中是否有参数，'' 也是一个参数
+		quote   rune // 当前所在的引号，0 表示不在引号中
+		escaped bool
+	)
+	for _, r := range command {
+		switch {
+		case escaped:
+			// 双引号中的反斜杠只转义 " \ $ 和 `
+			if quote == '"' && !strings.ContainsRune(`"\$`+"`", r) {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	switch {
+	case escaped:
+		return nil, errors.New("unterminated escape at the end of the command")
+	case quote != 0:
+		return nil, fmt.Errorf("unterminated %c quote in the command", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// requestContext 为单个请求创建带超时的上下文
+func (o *Options) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, o.Timeout)
+}
+
+// RequestContext 为单个请求创建带超时的上下文
+func (c *Client) RequestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return c.opts.requestContext(ctx)
+}
+
+// FindTool 从服务端的工具列表中查找指定工具
+func (c *Client) FindTool(ctx context.Context, name string) (*mcp.Tool, error) {
+	reqCtx, cancel := c.RequestContext(ctx)
+	defer cancel()
+
+	result, err := c.ListTools(reqCtx, mcp.ListToolsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Tools {
+		if result.Tools[i].Name == name {
+			return &result.Tools[i], nil
+		}
+	}
+	return nil, fmt.Errorf("tool %q not found on server", name)
+}
@@ -0,0 +1,450 @@
+// Package sandbox 限定在配置目录内的文件系统访问
+//
+// 路径可以是某个根目录下的绝对路径，也可以是相对于第一个根目录的相对路径。所有操作都通过 os.Root 进行，
+// 指向根目录之外的符号链接和 .. 都会被拒绝。
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrOutside 路径不在任何允许的目录中
+	ErrOutside = errors.New("path is outside the allowed directories")
+	// ErrReadOnly 只读模式下拒绝修改
+	ErrReadOnly = errors.New("the file system tools are read-only")
+)
+
+// Options 沙箱配置
+type Options struct {
+	Roots        []string // 允许访问的目录
+	ReadOnly     bool     // 是否禁止写入和移动
+	MaxFileBytes int64    // 读写单个文件的最大字节数
+}
+
+// FS 限定在若干根目录内的文件系统
+type FS struct {
+	roots        []string
+	readOnly     bool
+	maxFileBytes int64
+}
+
+// New 创建沙箱，根目录在每次操作时打开，因此不要求创建时已经存在
+func New(opts Options) *FS {
+	roots := make([]string, 0, len(opts.Roots))
+	for _, root := range opts.Roots {
+		if abs, err := filepath.Abs(root); err == nil {
+			roots = append(roots, abs)
+		}
+	}
+	return &FS{roots: roots, readOnly: opts.ReadOnly, maxFileBytes: opts.MaxFileBytes}
+}
+
+// CheckRoot 检查目录能否作为根目录
+func CheckRoot(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+// Roots 返回根目录的绝对路径
+func (f *FS) Roots() []string {
+	return f.roots
+}
+
+// ReadOnly 是否为只读模式
+func (f *FS) ReadOnly() bool {
+	return f.readOnly
+}
+
+// MaxFileBytes 读写单个文件的最大字节数
+func (f *FS) MaxFileBytes() int64 {
+	return f.maxFileBytes
+}
+
+// Path 沙箱中的位置，Rel 是相对于根目录的本地路径，根目录本身为 "."
+type Path struct {
+	Root string
+	Rel  string
+}
+
+// String 返回绝对路径
+func (p Path) String() string {
+	return filepath.Join(p.Root, p.Rel)
+}
+
+// Resolve 将用户给出的路径解析为沙箱中的位置，空路径表示第一个根目录
+// 这里只做词法检查，符号链接在操作时由 os.Root 检查
+func (f *FS) Resolve(name string) (Path, error) {
+	if len(f.roots) == 0 {
+		return Path{}, errors.New("no directories are configured, set tools.filesystem.roots")
+	}
+	if !filepath.IsAbs(name) {
+		rel := filepath.Clean(name)
+		if rel != "." && !filepath.IsLocal(rel) {
+			return Path{}, fmt.Errorf("%s: %w", name, ErrOutside)
+		}
+		return Path{Root: f.roots[0], Rel: rel}, nil
+	}
+	// 根目录嵌套时使用最深的那个
+	var best Path
+	for _, root := range f.roots {
+		rel, err := filepath.Rel(root, filepath.Clean(name))
+		if err != nil || (rel != "." && !filepath.IsLocal(rel)) {
+			continue
+		}
+		if best.Root == "" || len(root) > len(best.Root) {
+			best = Path{Root: root, Rel: rel}
+		}
+	}
+	if best.Root == "" {
+		return Path{}, fmt.Errorf("%s: %w %s", name, ErrOutside, strings.Join(f.roots, ", "))
+	}
+	return best, nil
+}
+
+// open 打开路径所在的根目录
+func (f *FS) open(p Path) (*os.Root, error) {
+	root, err := os.OpenRoot(p.Root)
+	if err != nil {
+		return nil, fmt.Errorf("open root %s: %w", p.Root, err)
+	}
+	return root, nil
+}
+
+// wrap 将 os.Root 返回的错误转换为带绝对路径的错误
+func wrap(p Path, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("%s does not exist", p)
+	case errors.Is(err, fs.ErrExist):
+		return fmt.Errorf("%s already exists", p)
+	case errors.Is(err, fs.ErrPermission):
+		return fmt.Errorf("%s: permission denied", p)
+	case strings.Contains(err.Error(), "path escapes from parent"):
+		// os.Root 拒绝了指向根目录之外的符号链接
+		return fmt.Errorf("%s: a symbolic link leads outside the allowed directories", p)
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return fmt.Errorf("%s: %w", p, pathErr.Err)
+	}
+	return err
+}
+
+// Stat 返回文件信息，不跟随最后一级符号链接
+func (f *FS) Stat(name string) (Path, fs.FileInfo, error) {
+	p, err := f.Resolve(name)
+	if err != nil {
+		return p, nil, err
+	}
+	root, err := f.open(p)
+	if err != nil {
+		return p, nil, err
+	}
+	defer root.Close()
+	info, err := root.Lstat(p.Rel)
+	return p, info, wrap(p, err)
+}
+
+// Readlink 返回符号链接的目标
+func (f *FS) Readlink(p Path) (string, error) {
+	return os.Readlink(p.String())
+}
+
+// ReadFile 读取文件的前 limit 个字节（不超过 MaxFileBytes），truncated 表示文件更长
+func (f *FS) ReadFile(name string, limit int64) (p Path, data []byte, size int64, truncated bool, err error) {
+	if limit <= 0 || limit > f.maxFileBytes {
+		limit = f.maxFileBytes
+	}
+	p, err = f.Resolve(name)
+	if err != nil {
+		return p, nil, 0, false, err
+	}
+	root, err := f.open(p)
+	if err != nil {
+		return p, nil, 0, false, err
+	}
+	defer root.Close()
+
+	// 打开 FIFO 或设备会一直阻塞，在打开前拒绝不是普通文件的路径
+	info, err := root.Stat(p.Rel)
+	if err != nil {
+		return p, nil, 0, false, wrap(p, err)
+	}
+	if !info.Mode().IsRegular() {
+		return p, nil, 0, false, fmt.Errorf("%s is not a regular file", p)
+	}
+	file, err := root.Open(p.Rel)
+	if err != nil {
+		return p, nil, 0, false, wrap(p, err)
+	}
+	defer file.Close()
+	// 再检查一次打开的文件，防止路径在两次检查之间被替换
+	info, err = file.Stat()
+	if err != nil {
+		return p, nil, 0, false, wrap(p, err)
+	}
+	if !info.Mode().IsRegular() {
+		return p, nil, 0, false, fmt.Errorf("%s is not a regular file", p)
+	}
+	data, err = io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return p, nil, 0, false, wrap(p, err)
+	}
+	if int64(len(data)) > limit {
+		data, truncated = data[:limit], true
+	}
+	return p, data, info.Size(), truncated, nil
+}
+
+// 写入方式
+const (
+	WriteOverwrite = "overwrite" // 覆盖已有文件
+	WriteAppend    = "append"    // 追加到文件末尾
+	WriteCreate    = "create"    // 只创建新文件，文件已存在时失败
+)
+
+// WriteFile 写入文件，createDirs 为 true 时创建缺少的上级目录；created 表示文件是新建的
+func (f *FS) WriteFile(name string, data []byte, mode string, createDirs bool) (p Path, created bool, err error) {
+	if f.readOnly {
+		return p, false, ErrReadOnly
+	}
+	if int64(len(data)) > f.maxFileBytes {
+		return p, false, fmt.Errorf("the content is %d bytes, the limit is %d", len(data), f.maxFileBytes)
+	}
+	flags := os.O_WRONLY | os.O_CREATE
+	switch mode {
+	case WriteOverwrite:
+		flags |= os.O_TRUNC
+	case WriteAppend:
+		flags |= os.O_APPEND
+	case WriteCreate:
+		flags |= os.O_EXCL
+	default:
+		return p, false, fmt.Errorf("unknown mode %q, must be one of overwrite, append, create", mode)
+	}
+	if p, err = f.Resolve(name); err != nil {
+		return p, false, err
+	}
+	if p.Rel == "." {
+		return p, false, fmt.Errorf("%s is a directory", p)
+	}
+	root, err := f.open(p)
+	if err != nil {
+		return p, false, err
+	}
+	defer root.Close()
+
+	if createDirs {
+		if err := mkdirAll(root, p, filepath.Dir(p.Rel)); err != nil {
+			return p, false, err
+		}
+	}
+	info, statErr := root.Stat(p.Rel)
+	created = errors.Is(statErr, fs.ErrNotExist)
+	switch {
+	case statErr != nil:
+	case info.IsDir():
+		return p, false, fmt.Errorf("%s is a directory", p)
+	case !info.Mode().IsRegular():
+		// 以写方式打开没有读者的 FIFO 会一直阻塞
+		return p, false, fmt.Errorf("%s is not a regular file", p)
+	}
+	file, err := root.OpenFile(p.Rel, flags, 0o644)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return p, false, fmt.Errorf("the directory of %s does not exist, set create_dirs to create it", p)
+		}
+		return p, false, wrap(p, err)
+	}
+	if mode == WriteAppend {
+		// 追加后的文件同样不能超过大小限制，使用打开的文件查询大小，避免和路径上的检查之间被替换
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return p, created, wrap(p, err)
+		}
+		if info.Size()+int64(len(data)) > f.maxFileBytes {
+			file.Close()
+			return p, created, fmt.Errorf("%s is %d bytes, appending %d bytes exceeds the limit of %d", p, info.Size(), len(data), f.maxFileBytes)
+		}
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return p, created, wrap(p, err)
+	}
+	return p, created, wrap(p, file.Close())
+}
+
+// mkdirAll 在根目录内逐级创建目录
+func mkdirAll(root *os.Root, p Path, dir string) error {
+	if dir == "." {
+		return nil
+	}
+	current := ""
+	for _, part := range strings.Split(dir, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		err := root.Mkdir(current, 0o755)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return wrap(Path{Root: p.Root, Rel: current}, err)
+		}
+		info, err := root.Stat(current)
+		if err != nil {
+			return wrap(Path{Root: p.Root, Rel: current}, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", Path{Root: p.Root, Rel: current})
+		}
+	}
+	return nil
+}
+
+// Walk 遍历目录，depth 为条目相对于目录的层级，直接位于目录下的条目为 1；不进入符号链接指向的目录
+// 不会进入层级达到 maxDepth 的子目录；fn 返回 fs.SkipDir 时跳过该目录，返回 fs.SkipAll 时停止
+func (f *FS) Walk(name string, maxDepth int, fn func(p Path, entry fs.DirEntry, depth int) error) (Path, error) {
+	p, err := f.Resolve(name)
+	if err != nil {
+		return p, err
+	}
+	root, err := f.open(p)
+	if err != nil {
+		return p, err
+	}
+	defer root.Close()
+
+	info, err := root.Stat(p.Rel)
+	if err != nil {
+		return p, wrap(p, err)
+	}
+	if !info.IsDir() {
+		return p, fmt.Errorf("%s is not a directory", p)
+	}
+	err = fs.WalkDir(root.FS(), filepath.ToSlash(p.Rel), func(name string, entry fs.DirEntry, err error) error {
+		if name == filepath.ToSlash(p.Rel) {
+			return err
+		}
+		if err != nil {
+			// 无法读取的子目录直接跳过
+			return nil
+		}
+		rel := filepath.FromSlash(name)
+		fromStart, _ := filepath.Rel(p.Rel, rel)
+		depth := strings.Count(fromStart, string(filepath.Separator)) + 1
+		if err := fn(Path{Root: p.Root, Rel: rel}, entry, depth); err != nil {
+			return err
+		}
+		if entry.IsDir() && depth >= maxDepth {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	return p, wrap(p, err)
+}
+
+// Move 移动或重命名文件和目录，overwrite 为 true 时替换已存在的目标文件。
+// os.Root 在 Go 1.24 中没有 Rename，Move 先检查实际路径再对其调用 os.Rename，
+// 两步之间如果有进程把根目录内的上级目录替换为指向外部的符号链接，移动可能越出根目录；
+// 根目录内有其他不可信的写入者时应当以 read_only 方式配置
+func (f *FS) Move(source, destination string, overwrite bool) (Path, Path, error) {
+	if f.readOnly {
+		return Path{}, Path{}, ErrReadOnly
+	}
+	src, err := f.Resolve(source)
+	if err != nil {
+		return src, Path{}, err
+	}
+	dst, err := f.Resolve(destination)
+	if err != nil {
+		return src, dst, err
+	}
+	if src.Rel == "." || dst.Rel == "." {
+		return src, dst, errors.New("a configured root directory cannot be moved or replaced")
+	}
+	if _, _, err := f.Stat(source); err != nil {
+		return src, dst, err
+	}
+	if _, info, err := f.Stat(destination); err == nil {
+		if !overwrite {
+			return src, dst, fmt.Errorf("%s already exists, set overwrite to replace it", dst)
+		}
+		if info.IsDir() {
+			return src, dst, fmt.Errorf("%s is a directory and cannot be replaced", dst)
+		}
+	}
+
+	// os.Root 没有 Rename，在实际路径上确认上级目录仍在根目录内后再调用 os.Rename
+	from, err := hostPath(src)
+	if err != nil {
+		return src, dst, err
+	}
+	to, err := hostPath(dst)
+	if err != nil {
+		return src, dst, err
+	}
+	if err := os.Rename(from, to); err != nil {
+		var linkErr *os.LinkError
+		if errors.As(err, &linkErr) {
+			err = linkErr.Err
+		}
+		return src, dst, fmt.Errorf("move %s to %s: %w", src, dst, err)
+	}
+	return src, dst, nil
+}
+
+// hostPath 解析上级目录中的符号链接，返回位于根目录内的实际路径
+func hostPath(p Path) (string, error) {
+	rootReal, err := filepath.EvalSymlinks(p.Root)
+	if err != nil {
+		return "", wrap(p, err)
+	}
+	parent, err := filepath.EvalSymlinks(filepath.Join(p.Root, filepath.Dir(p.Rel)))
+	if err != nil {
+		return "", fmt.Errorf("the directory of %s does not exist", p)
+	}
+	if rel, err := filepath.Rel(rootReal, parent); err != nil || (rel != "." && !filepath.IsLocal(rel)) {
+		return "", fmt.Errorf("%s: a symbolic link leads outside the allowed directories", p)
+	}
+	return filepath.Join(parent, filepath.Base(p.Rel)), nil
+}
+
+// binarySample 判断二进制文件时检查的字节数
+const binarySample = 8000
+
+// IsBinary 根据开头的内容判断是否为二进制数据：含有 NUL 字节或不是合法的 UTF-8
+func IsBinary(data []byte) bool {
+	sample := data[:min(len(data), binarySample)]
+	for _, c := range sample {
+		if c == 0 {
+			return true
+		}
+	}
+	if utf8.Valid(sample) {
+		return false
+	}
+	// 截断处可能切开了最后一个多字节字符
+	for k := 1; k < utf8.UTFMax && k < len(sample); k++ {
+		if utf8.Valid(sample[:len(sample)-k]) && !utf8.FullRune(sample[len(sample)-k:]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package textutil
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// 大小写和命名风格
+const (
+	CaseUpper    = "upper"    // HELLO WORLD
+	CaseLower    = "lower"    // hello world
+	CaseTitle    = "title"    // Hello World
+	CaseSentence = "sentence" // Hello world. Second sentence
+	CaseCamel    = "camel"    // helloWorld
+	CasePascal   = "pascal"   // HelloWorld
+	CaseSnake    = "snake"    // hello_world
+	CaseKebab    = "kebab"    // hello-world
+	CaseConstant = "constant" // HELLO_WORLD
+)
+
+// Cases 支持的大小写和命名风格
+var Cases = []string{CaseUpper, CaseLower, CaseTitle, CaseSentence, CaseCamel, CasePascal, CaseSnake, CaseKebab, CaseConstant}
+
+// ConvertCase 转换大小写，lang 为 BCP 47 语言标签（如 tr、de），影响土耳其语 i 和德语 ß 等特殊规则，为空时不使用语言规则
+// camel、pascal、snake、kebab 和 constant 先按空白、标点和大小写变化拆分单词，再按风格拼接
+func ConvertCase(s string, style string, lang string) (string, error) {
+	tag := language.Und
+	if lang != "" {
+		var err error
+		if tag, err = language.Parse(lang); err != nil {
+			return "", fmt.Errorf("invalid language tag %q", lang)
+		}
+	}
+	upper, lower, title := cases.Upper(tag), cases.Lower(tag), cases.Title(tag)
+
+	switch style {
+	case CaseUpper:
+		return upper.String(s), nil
+	case CaseLower:
+		return lower.String(s), nil
+	case CaseTitle:
+		return title.String(s), nil
+	case CaseSentence:
+		return sentenceCase(lower.String(s), upper), nil
+	}
+
+	words := SplitWords(s)
+	for i, w := range words {
+		words[i] = lower.String(w)
+	}
+	switch style {
+	case CaseCamel, CasePascal:
+		for i, w := range words {
+			if i > 0 || style == CasePascal {
+				words[i] = title.String(w)
+			}
+		}
+		return strings.Join(words, ""), nil
+	case CaseSnake:
+		return strings.Join(words, "_"), nil
+	case CaseKebab:
+		return strings.Join(words, "-"), nil
+	case CaseConstant:
+		return upper.String(strings.Join(words, "_")), nil
+	default:
+		return "", fmt.Errorf("unknown case %q, must be one of %s", style, strings.Join(Cases, ", "))
+	}
+}
+
+// sentenceCase 将文本开头和每个 . ! ? 之后的第一个字母大写
+func sentenceCase(s string, upper cases.Caser) string {
+	var b strings.Builder
+	capitalize := true
+	for _, r := range s {
+		switch {
+		case capitalize && unicode.IsLetter(r):
+			b.WriteString(upper.String(string(r)))
+			capitalize = false
+			continue
+		case r == '.' || r == '!' || r == '?' || r == '。' || r == '！' || r == '？':
+			capitalize = true
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SplitWords 按标识符风格拆分单词：非字母数字字符为分隔符，小写到大写和 HTTPServer 这样的缩写边界也会拆开
+func SplitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			words = append(words, string(runes[start:end]))
+			start = -1
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r) {
+			flush(i)
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			// fooBar 和 foo1Bar 在 B 前拆分；HTTPServer 在 S 前拆分
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush(i)
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(runes))
+	return words
+}
@@ -0,0 +1,71 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Counts 文本的统计信息
+type Counts struct {
+	Bytes     int `json:"bytes"`
+	Runes     int `json:"runes"`     // Unicode 码点数
+	Graphemes int `json:"graphemes"` // 字形簇数，即用户看到的字符数
+	Words     int `json:"words"`
+	Lines     int `json:"lines"`
+	Width     int `json:"width"` // 最长一行的显示宽度
+}
+
+// Count 统计文本；连续的字母、数字（可以包含 ' 和 -）为一个单词，汉字、假名等没有空格分词的文字每个字符计为一个单词
+func Count(s string) Counts {
+	c := Counts{
+		Bytes:     len(s),
+		Runes:     utf8.RuneCountInString(s),
+		Graphemes: len(Graphemes(s)),
+		Words:     countWords(s),
+	}
+	if s == "" {
+		return c
+	}
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	// 末尾的换行不算新的一行
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	c.Lines = len(lines)
+	for _, line := range lines {
+		c.Width = max(c.Width, Width(line))
+	}
+	return c
+}
+
+func countWords(s string) int {
+	words := 0
+	inWord := false
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case isIdeographic(r):
+			words++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+				inWord = true
+			}
+		case unicode.Is(unicode.Mn, r) && inWord:
+			// 组合字符属于当前单词
+		case (r == '\'' || r == '’' || r == '-') && inWord && i+1 < len(runes) &&
+			(unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1])):
+			// don't、well-known 中的 ' 和 - 不拆分单词
+		default:
+			inWord = false
+		}
+	}
+	return words
+}
+
+// isIdeographic 是否为不使用空格分词的文字
+func isIdeographic(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar)
+}
@@ -0,0 +1,59 @@
+// Package textutil Unicode 文本处理，按字形簇（用户看到的一个字符）而不是码点处理组合字符和 emoji 序列
+package textutil
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/width"
+)
+
+// Graphemes 将文本拆分为字形簇，例如 "é" 和 "👩‍👩‍👧" 各自是一个字形簇
+func Graphemes(s string) []string {
+	var clusters []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+	return clusters
+}
+
+// Reverse 按字形簇反转文本，组合字符、emoji 修饰符和 ZWJ 序列保持完整
+// \r\n 是一个字形簇，反转后仍为 \r\n
+func Reverse(s string) string {
+	clusters := Graphemes(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		b.WriteString(clusters[i])
+	}
+	return b.String()
+}
+
+// Width 返回文本在等宽终端中的显示宽度，东亚宽字符和全角字符占 2 列，控制字符和组合字符不占宽度
+func Width(s string) int {
+	total := 0
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		total += clusterWidth(g.Runes())
+	}
+	return total
+}
+
+// clusterWidth 返回一个字形簇的显示宽度，由第一个码点决定；emoji 变体选择符 U+FE0F 使其占 2 列
+func clusterWidth(runes []rune) int {
+	if len(runes) == 0 || runes[0] < 0x20 || runes[0] == 0x7f {
+		return 0
+	}
+	for _, r := range runes[1:] {
+		if r == 0xfe0f {
+			return 2
+		}
+	}
+	switch width.LookupRune(runes[0]).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,66 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugify 生成 URL 友好的 slug：分解后去掉拉丁、希腊和西里尔字母上的变音符号（é → e），转为小写，
+// 其他字符替换为 separator；其他文字（如中文、日文的浊音符号）会被保留，
+// maxLength 大于 0 时在不截断单词的前提下限制长度（按字符计）
+func Slugify(s string, separator string, maxLength int) string {
+	var words []string
+	var word strings.Builder
+	// strip 表示前一个基字符之后的变音符号需要去掉
+	strip := true
+	flush := func() {
+		if word.Len() > 0 {
+			// 重新组合保留下来的符号，例如 カ + ゙ → ガ，长度按组合后的字符计
+			words = append(words, norm.NFC.String(word.String()))
+			word.Reset()
+		}
+	}
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			if !strip && word.Len() > 0 {
+				word.WriteRune(r)
+			}
+		case unicode.IsMark(r):
+			// 天城文的元音符号等占位的组合符号属于单词的一部分
+			if word.Len() > 0 {
+				word.WriteRune(r)
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(unicode.ToLower(r))
+			strip = unicode.IsDigit(r) || unicode.In(r, unicode.Latin, unicode.Greek, unicode.Cyrillic)
+		default:
+			flush()
+			strip = true
+		}
+	}
+	flush()
+
+	slug := strings.Join(words, separator)
+	if maxLength <= 0 || len([]rune(slug)) <= maxLength {
+		return norm.NFC.String(slug)
+	}
+	// 尽量在单词边界截断，第一个单词过长时直接截断
+	var b strings.Builder
+	for i, w := range words {
+		next := w
+		if i > 0 {
+			next = separator + w
+		}
+		if len([]rune(b.String()+next)) > maxLength {
+			if i == 0 {
+				b.WriteString(string([]rune(w)[:maxLength]))
+			}
+			break
+		}
+		b.WriteString(next)
+	}
+	return norm.NFC.String(b.String())
+}
@@ -0,0 +1,100 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// Wrap 按显示宽度折行，保留原有的换行；在空白处断行，单词超过 width 时按字形簇拆开
+// 宽字符（如汉字）之间可以直接断行
+func Wrap(s string, width int) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// token 折行的最小单位：一个单词、一段空白或一个宽字符
+type token struct {
+	text  string
+	width int
+	space bool
+}
+
+func wrapLine(line string, width int) string {
+	var out []string
+	var current strings.Builder
+	currentWidth := 0
+	newLine := func() {
+		out = append(out, strings.TrimRightFunc(current.String(), unicode.IsSpace))
+		current.Reset()
+		currentWidth = 0
+	}
+
+	for _, t := range tokenize(line) {
+		switch {
+		case t.space:
+			// 行首的空白（缩进）保留，断行处的空白丢弃
+			if currentWidth > 0 || len(out) == 0 {
+				current.WriteString(t.text)
+				currentWidth += t.width
+			}
+			continue
+		case currentWidth+t.width <= width:
+		case t.width <= width:
+			newLine()
+		default:
+			// 单词本身超过一行，按字形簇拆开
+			for _, cluster := range Graphemes(t.text) {
+				w := Width(cluster)
+				if currentWidth+w > width && currentWidth > 0 {
+					newLine()
+				}
+				current.WriteString(cluster)
+				currentWidth += w
+			}
+			continue
+		}
+		current.WriteString(t.text)
+		currentWidth += t.width
+	}
+	if current.Len() > 0 || len(out) == 0 {
+		out = append(out, strings.TrimRightFunc(current.String(), unicode.IsSpace))
+	}
+	return strings.Join(out, "\n")
+}
+
+// tokenize 将一行拆分为单词、空白和宽字符
+func tokenize(line string) []token {
+	var tokens []token
+	var word strings.Builder
+	wordWidth := 0
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, token{text: word.String(), width: wordWidth})
+			word.Reset()
+			wordWidth = 0
+		}
+	}
+	g := uniseg.NewGraphemes(line)
+	for g.Next() {
+		runes := g.Runes()
+		w := clusterWidth(runes)
+		switch {
+		case unicode.IsSpace(runes[0]):
+			flush()
+			tokens = append(tokens, token{text: g.Str(), width: max(w, 1), space: true})
+		case w == 2:
+			flush()
+			tokens = append(tokens, token{text: g.Str(), width: w})
+		default:
+			word.WriteString(g.Str())
+			wordWidth += w
+		}
+	}
+	flush()
+	return tokens
+}
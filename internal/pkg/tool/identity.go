@@ -0,0 +1,54 @@
+// Package tool identity.go
+package tool
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+)
+
+// ClientIdentity 通过 TLS 客户端证书认证的调用方身份
+type ClientIdentity struct {
+	CommonName   string   `json:"commonName"`
+	Subject      string   `json:"subject"`
+	Issuer       string   `json:"issuer"`
+	SerialNumber string   `json:"serialNumber"`
+	DNSNames     []string `json:"dnsNames,omitempty"`
+	Emails       []string `json:"emails,omitempty"`
+	URIs         []string `json:"uris,omitempty"`
+	Fingerprint  string   `json:"fingerprint"` // 证书的 SHA-256 指纹
+	Verified     bool     `json:"verified"`    // 证书是否已通过 CA 校验
+}
+
+type clientIdentityKey struct{}
+
+// NewClientIdentity 从客户端证书创建调用方身份
+func NewClientIdentity(cert *x509.Certificate, verified bool) *ClientIdentity {
+	sum := sha256.Sum256(cert.Raw)
+	id := &ClientIdentity{
+		CommonName:   cert.Subject.CommonName,
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		DNSNames:     cert.DNSNames,
+		Emails:       cert.EmailAddresses,
+		Fingerprint:  hex.EncodeToString(sum[:]),
+		Verified:     verified,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+	}
+	return id
+}
+
+// WithClientIdentity 将调用方身份保存到 context
+func WithClientIdentity(ctx context.Context, id *ClientIdentity) context.Context {
+	return context.WithValue(ctx, clientIdentityKey{}, id)
+}
+
+// ClientIdentityFromContext 返回工具调用方的身份，未使用客户端证书时返回 false
+func ClientIdentityFromContext(ctx context.Context) (*ClientIdentity, bool) {
+	id, ok := ctx.Value(clientIdentityKey{}).(*ClientIdentity)
+	return id, ok && id != nil
+}
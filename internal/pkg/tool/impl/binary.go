@@ -0,0 +1,77 @@
+// Package impl binary.go
+package impl
+
+import (
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/codec"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 编码和摘要工具的输入大小上限
+const (
+	maxBinaryInputBytes = 1 << 20                      // 解码后的输入字节数
+	maxBinaryTextBytes  = 2*maxBinaryInputBytes + 1024 // 参数文本的字节数，hex 形式是原始数据的两倍
+)
+
+// 二进制参数的编码方式，utf8 表示直接使用文本本身
+var inputEncodings = []string{"utf8", codec.Base64, codec.Hex}
+
+// inputEncodingParam 声明二进制参数的编码方式参数
+func inputEncodingParam(name, target string) mcp.ToolOption {
+	return mcp.WithString(name,
+		mcp.Description(fmt.Sprintf("How %s is given: utf8 uses the text as is, base64 and hex pass arbitrary bytes", target)),
+		mcp.Enum(inputEncodings...),
+		mcp.DefaultString("utf8"),
+	)
+}
+
+// binaryArg 读取二进制参数，encodingName 为其编码方式参数的名称；optional 为 true 时参数缺失返回 nil
+func binaryArg(request mcp.CallToolRequest, name, encodingName string, optional bool) ([]byte, error) {
+	value, ok := request.GetArguments()[name]
+	if !ok || value == nil {
+		if optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("required argument %q not found", name)
+	}
+	text, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a string, got %T", name, value)
+	}
+	if len(text) > maxBinaryTextBytes {
+		return nil, fmt.Errorf("%s is %d bytes, the limit is %d", name, len(text), maxBinaryTextBytes)
+	}
+
+	var data []byte
+	switch encoding := request.GetString(encodingName, "utf8"); encoding {
+	case "utf8":
+		data = []byte(text)
+	case codec.Base64, codec.Hex:
+		decoded, err := codec.Decode(encoding, text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		data = decoded
+	default:
+		return nil, fmt.Errorf("unknown %s %q, must be one of utf8, base64, hex", encodingName, encoding)
+	}
+	if len(data) > maxBinaryInputBytes {
+		return nil, fmt.Errorf("%s is %d bytes, the limit is %d", name, len(data), maxBinaryInputBytes)
+	}
+	return data, nil
+}
+
+// isBinary 判断数据能否作为文本返回：不是合法 UTF-8 或含有换行和制表符以外的控制字符时视为二进制
+func isBinary(data []byte) bool {
+	if !utf8.Valid(data) {
+		return true
+	}
+	for _, c := range data {
+		if c < 0x20 && c != '\n' && c != '\r' && c != '\t' || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Package impl business_days.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/datetime"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// BusinessDaysTool 工作日计算工具
+type BusinessDaysTool struct {
+	tool.BaseTool
+}
+
+// BusinessDaysResult 工作日计算的结构化结果
+type BusinessDaysResult struct {
+	Operation    string   `json:"operation"`
+	Start        TimeInfo `json:"start"`
+	End          TimeInfo `json:"end"`
+	BusinessDays int      `json:"business_days"`
+}
+
+// NewBusinessDaysTool 创建工作日计算工具
+func NewBusinessDaysTool() tool.Handler {
+	description := "Add business days to a date or count the business days between two dates, skipping weekends and holidays"
+	businessDaysTool := mcp.NewTool("business_days",
+		mcp.WithDescription(description),
+		mcp.WithString("operation",
+			mcp.Required(),
+			mcp.Description("add moves start forward (or backward for negative days) by business days, not counting start itself; "+
+				"count returns the business days from start to end, including both"),
+			mcp.Enum("add", "count"),
+		),
+		mcp.WithString("start",
+			mcp.Required(),
+			mcp.Description("The start date: "+dateInputDescription),
+		),
+		mcp.WithNumber("days",
+			mcp.Description("Number of business days to add for add, may be negative"),
+			mcp.Min(-datetime.MaxBusinessDays),
+			mcp.Max(datetime.MaxBusinessDays),
+		),
+		mcp.WithString("end",
+			mcp.Description("End date for count"),
+		),
+		mcp.WithArray("weekend",
+			mcp.Description("Weekend days, defaults to saturday and sunday"),
+			mcp.WithStringItems(mcp.Enum("monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday")),
+		),
+		mcp.WithArray("holidays",
+			mcp.Description("Non-working dates in 2006-01-02 form"),
+			mcp.WithStringItems(),
+		),
+		timezoneParam("Time zone for inputs without an offset and for deciding which calendar day a time falls on"),
+		dayFirstParam(),
+		mcp.WithOutputSchema[BusinessDaysResult](),
+	)
+
+	return &BusinessDaysTool{
+		BaseTool: tool.NewBaseTool(
+			"business_days",
+			description,
+			businessDaysTool),
+	}
+}
+
+// Handle 按 operation 计算工作日
+func (b *BusinessDaysTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	op, err := request.RequireString("operation")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	loc, err := locationArg(request, "timezone")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	start, _, err := timeArg(request, "start", loc)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	calendar, err := datetime.NewCalendar(request.GetStringSlice("weekend", nil), request.GetStringSlice("holidays", nil))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := BusinessDaysResult{Operation: op, Start: newTimeInfo(start)}
+	switch op {
+	case "add":
+		days, err := signedInt(request.GetArguments(), "days", datetime.MaxBusinessDays)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		end, err := calendar.AddBusinessDays(start, days)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result.End = newTimeInfo(end)
+		result.BusinessDays = days
+		return mcp.NewToolResultStructured(result, result.End.Date), nil
+	case "count":
+		if _, err := request.RequireString("end"); err != nil {
+			return mcp.NewToolResultError("end is required for count"), nil
+		}
+		end, _, err := timeArg(request, "end", loc)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if result.BusinessDays, err = calendar.CountBusinessDays(start, end); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result.End = newTimeInfo(end)
+		return mcp.NewToolResultStructured(result, strconv.Itoa(result.BusinessDays)), nil
+	default:
+		return mcp.NewToolResultErrorf("unknown operation %q, must be one of add, count", op), nil
+	}
+}
+
+// signedInt 读取可以为负数的必填整数参数
+func signedInt(args map[string]any, name string, limit int) (int, error) {
+	f, ok := args[name].(float64)
+	if !ok {
+		return 0, fmt.Errorf("%s must be an integer between %d and %d", name, -limit, limit)
+	}
+	if f != float64(int(f)) || f < float64(-limit) || f > float64(limit) {
+		return 0, fmt.Errorf("%s must be an integer between %d and %d", name, -limit, limit)
+	}
+	return int(f), nil
+}
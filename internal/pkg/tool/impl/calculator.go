@@ -4,7 +4,12 @@ package impl
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/big"
 	"mcp-go-tutorials/internal/pkg/tool"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -14,29 +19,76 @@ type CalculatorTool struct {
 	tool.BaseTool
 }
 
+// CalculatorResult 计算器的结构化结果
+type CalculatorResult struct {
+	Operation   string  `json:"operation"`
+	Precision   string  `json:"precision"`
+	Result      string  `json:"result"`                // 按 scale 和 rounding 格式化后的结果
+	Exact       bool    `json:"exact"`                 // result 是否等于精确的数学结果
+	Value       float64 `json:"value"`                 // result 的 float64 近似值
+	Numerator   string  `json:"numerator,omitempty"`   // rational 精度下的分子
+	Denominator string  `json:"denominator,omitempty"` // rational 精度下的分母
+}
+
+// 计算器支持的操作
+var calculatorOperations = []string{
+	"add", "subtract", "multiply", "divide", "int_divide", "modulo", "power",
+	"gcd", "lcm", "and", "or", "xor", "shift_left", "shift_right",
+}
+
+// integerOperations 只接受整数参数的操作，在所有精度下都使用任意大小的整数计算
+var integerOperations = []string{"gcd", "lcm", "and", "or", "xor", "shift_left", "shift_right"}
+
+// maxResultBits 整数结果允许的最大位数，防止 power 和 shift_left 耗尽内存
+const maxResultBits = 1 << 20
+
+// maxExactFloatInt float 精度下可以精确表示的最大整数 2^53
+var maxExactFloatInt = new(big.Int).Lsh(big.NewInt(1), 53)
+
 // NewCalculatorTool 创建新的计算器工具实例
 func NewCalculatorTool() tool.Handler {
 	calculatorTool := mcp.NewTool("calculate",
-		mcp.WithDescription("Perform basic arithmetic operations"),
+		mcp.WithDescription("Perform arithmetic, integer and bitwise operations on two numbers"),
 		mcp.WithString("operation",
 			mcp.Required(),
-			mcp.Description("The operation to perform (add, subtract, multiply, divide)"),
-			mcp.Enum("add", "subtract", "multiply", "divide"),
+			mcp.Description("The operation to perform. int_divide truncates toward zero and modulo takes the sign of x; "+
+				"power accepts a non-integer y only in float precision; "+strings.Join(integerOperations, ", ")+
+				" require integer x and y"),
+			mcp.Enum(calculatorOperations...),
 		),
 		mcp.WithNumber("x",
 			mcp.Required(),
-			mcp.Description("First number"),
+			mcp.Description("First number; pass a string such as \"0.1\", \"1/3\" or a large integer to keep it exact"),
+			numberOrString(),
 		),
 		mcp.WithNumber("y",
 			mcp.Required(),
-			mcp.Description("Second number"),
+			mcp.Description("Second number; pass a string such as \"0.1\", \"1/3\" or a large integer to keep it exact"),
+			numberOrString(),
 		),
+		mcp.WithString("precision",
+			mcp.Description("float uses float64; decimal and rational are exact; integer uses arbitrary-size integers and rounds division results"),
+			mcp.Enum(precisions...),
+			mcp.DefaultString(PrecisionFloat),
+		),
+		mcp.WithNumber("scale",
+			mcp.Description("Number of decimal places in float and decimal results; by default results are not rounded "+
+				"except non-terminating decimals, which use "+strconv.Itoa(defaultDecimalScale)+" places"),
+			mcp.Min(0),
+			mcp.Max(maxScale),
+		),
+		mcp.WithString("rounding",
+			mcp.Description("Rounding mode used for scale and integer division"),
+			mcp.Enum(roundingModes...),
+			mcp.DefaultString(RoundHalfUp),
+		),
+		mcp.WithOutputSchema[CalculatorResult](),
 	)
 
 	return &CalculatorTool{
 		BaseTool: tool.NewBaseTool(
 			"calculate",
-			"Perform basic arithmetic operations",
+			"Perform arithmetic, integer and bitwise operations on two numbers",
 			calculatorTool),
 	}
 }
@@ -47,32 +99,305 @@ func (c *CalculatorTool) Handle(_ context.Context, request mcp.CallToolRequest)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	x, err := request.RequireFloat("x")
+	precision := request.GetString("precision", PrecisionFloat)
+	if !slices.Contains(precisions, precision) {
+		return mcp.NewToolResultErrorf("unknown precision %q, must be one of %s", precision, strings.Join(precisions, ", ")), nil
+	}
+	rounding := request.GetString("rounding", RoundHalfUp)
+	if !slices.Contains(roundingModes, rounding) {
+		return mcp.NewToolResultErrorf("unknown rounding %q, must be one of %s", rounding, strings.Join(roundingModes, ", ")), nil
+	}
+	args := request.GetArguments()
+	scale, err := optionalInt(args, "scale", maxScale)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	y, err := request.RequireFloat("y")
+	if !slices.Contains(calculatorOperations, op) {
+		return mcp.NewToolResultErrorf("unknown operation %q, must be one of %s", op, strings.Join(calculatorOperations, ", ")), nil
+	}
+
+	var result *CalculatorResult
+	switch {
+	case slices.Contains(integerOperations, op):
+		result, err = calculateIntegerOnly(op, args, precision)
+	case precision == PrecisionFloat:
+		result, err = calculateFloat(op, args, scale, rounding)
+	case precision == PrecisionInteger:
+		result, err = calculateInteger(op, args, rounding)
+	default:
+		result, err = calculateRational(op, args, precision, scale, rounding)
+	}
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	var result float64
+	result.Operation = op
+	result.Precision = precision
+	return mcp.NewToolResultStructured(result, result.Result), nil
+}
+
+// calculateFloat 使用 float64 计算，exact 通过与精确的有理数结果比较得到
+func calculateFloat(op string, args map[string]any, scale int, rounding string) (*CalculatorResult, error) {
+	x, err := parseFloat("x", args["x"])
+	if err != nil {
+		return nil, err
+	}
+	y, err := parseFloat("y", args["y"])
+	if err != nil {
+		return nil, err
+	}
+
+	var value float64
 	switch op {
 	case "add":
-		result = x + y
+		value = x + y
 	case "subtract":
-		result = x - y
+		value = x - y
 	case "multiply":
-		result = x * y
-	case "divide":
+		value = x * y
+	case "divide", "int_divide", "modulo":
 		if y == 0 {
-			return mcp.NewToolResultError("cannot divide by zero"), nil
+			return nil, errDivisionByZero
+		}
+		switch op {
+		case "divide":
+			value = x / y
+		case "int_divide":
+			value = math.Trunc(x / y)
+		default:
+			value = math.Mod(x, y)
 		}
-		result = x / y
+	case "power":
+		value = math.Pow(x, y)
 	default:
-		return mcp.NewToolResultError("unknown operation: " + op), nil
+		return nil, fmt.Errorf("unknown operation: %s", op)
 	}
+	if math.IsNaN(value) {
+		return nil, fmt.Errorf("%s: result is not a real number", op)
+	}
+	if math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%s: result overflows float64, use the decimal or integer precision", op)
+	}
+
+	result := &CalculatorResult{Result: strconv.FormatFloat(value, 'f', -1, 64)}
+	if scale >= 0 {
+		result.Result, _ = formatDecimal(new(big.Rat).SetFloat64(value), scale, rounding)
+	}
+	formatted, _ := new(big.Rat).SetString(result.Result)
+	result.Value, _ = formatted.Float64()
+
+	xr, _ := parseRat("x", args["x"])
+	yr, _ := parseRat("y", args["y"])
+	if exact, err := rationalOp(op, xr, yr); err == nil {
+		result.Exact = formatted.Cmp(exact) == 0
+	}
+	return result, nil
+}
+
+// calculateRational 使用有理数精确计算，decimal 精度输出十进制小数，rational 精度输出分数
+func calculateRational(op string, args map[string]any, precision string, scale int, rounding string) (*CalculatorResult, error) {
+	x, err := parseRat("x", args["x"])
+	if err != nil {
+		return nil, err
+	}
+	y, err := parseRat("y", args["y"])
+	if err != nil {
+		return nil, err
+	}
+	value, err := rationalOp(op, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CalculatorResult{}
+	if precision == PrecisionRational {
+		result.Result = value.RatString()
+		result.Exact = true
+		result.Numerator = value.Num().String()
+		result.Denominator = value.Denom().String()
+		result.Value, _ = value.Float64()
+		return result, nil
+	}
+	result.Result, result.Exact = formatDecimal(value, scale, rounding)
+	formatted, _ := new(big.Rat).SetString(result.Result)
+	result.Value, _ = formatted.Float64()
+	return result, nil
+}
+
+// rationalOp 精确计算两个有理数
+func rationalOp(op string, x, y *big.Rat) (*big.Rat, error) {
+	switch op {
+	case "add":
+		return new(big.Rat).Add(x, y), nil
+	case "subtract":
+		return new(big.Rat).Sub(x, y), nil
+	case "multiply":
+		if x.Num().BitLen()+y.Num().BitLen() > maxResultBits || x.Denom().BitLen()+y.Denom().BitLen() > maxResultBits {
+			return nil, fmt.Errorf("multiply: result exceeds %d bits", maxResultBits)
+		}
+		return new(big.Rat).Mul(x, y), nil
+	case "divide", "int_divide", "modulo":
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		quo := new(big.Rat).Quo(x, y)
+		if op == "divide" {
+			return quo, nil
+		}
+		// 商向 0 截断，余数 x - y*trunc(x/y) 与 x 同号
+		trunc := new(big.Rat).SetInt(new(big.Int).Quo(quo.Num(), quo.Denom()))
+		if op == "int_divide" {
+			return trunc, nil
+		}
+		return new(big.Rat).Sub(x, new(big.Rat).Mul(y, trunc)), nil
+	case "power":
+		return ratPower(x, y)
+	default:
+		return nil, fmt.Errorf("unknown operation: %s", op)
+	}
+}
+
+// ratPower 计算有理数的整数次幂
+func ratPower(x, y *big.Rat) (*big.Rat, error) {
+	if !y.IsInt() {
+		return nil, fmt.Errorf("power requires an integer exponent in exact precision, got %s; use the float precision", y.RatString())
+	}
+	exp := y.Num()
+	if x.Sign() == 0 && exp.Sign() < 0 {
+		return nil, errDivisionByZero
+	}
+	bits := max(x.Num().BitLen(), x.Denom().BitLen())
+	if powerExceedsLimit(bits, exp) {
+		return nil, fmt.Errorf("power: result exceeds %d bits", maxResultBits)
+	}
+	abs := new(big.Int).Abs(exp)
+	num := new(big.Int).Exp(x.Num(), abs, nil)
+	den := new(big.Int).Exp(x.Denom(), abs, nil)
+	if exp.Sign() < 0 {
+		num, den = den, num
+	}
+	return new(big.Rat).SetFrac(num, den), nil
+}
+
+// powerExceedsLimit 判断 bits 位的底数做 exp 次幂后是否可能超过 maxResultBits，
+// 先用除法比较，避免 bits*exp 在 int64 上溢出
+func powerExceedsLimit(bits int, exp *big.Int) bool {
+	if bits <= 1 {
+		// 0、1 和 -1 的任意次幂都不会增长
+		return false
+	}
+	abs := new(big.Int).Abs(exp)
+	return !abs.IsInt64() || abs.Int64() > maxResultBits/int64(bits)
+}
+
+// calculateInteger 使用任意大小的整数计算，除法的商按 rounding 舍入
+func calculateInteger(op string, args map[string]any, rounding string) (*CalculatorResult, error) {
+	x, err := parseInt("x", args["x"])
+	if err != nil {
+		return nil, err
+	}
+	y, err := parseInt("y", args["y"])
+	if err != nil {
+		return nil, err
+	}
+
+	exact := true
+	value := new(big.Int)
+	switch op {
+	case "add":
+		value.Add(x, y)
+	case "subtract":
+		value.Sub(x, y)
+	case "multiply":
+		if x.BitLen()+y.BitLen() > maxResultBits {
+			return nil, fmt.Errorf("multiply: result exceeds %d bits", maxResultBits)
+		}
+		value.Mul(x, y)
+	case "divide", "int_divide", "modulo":
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		switch op {
+		case "divide":
+			value = roundQuotient(x, y, rounding)
+			exact = new(big.Int).Rem(x, y).Sign() == 0
+		case "int_divide":
+			value.Quo(x, y)
+		default:
+			value.Rem(x, y)
+		}
+	case "power":
+		if y.Sign() < 0 {
+			return nil, fmt.Errorf("power requires a non-negative exponent in integer precision, got %s", y)
+		}
+		if powerExceedsLimit(x.BitLen(), y) {
+			return nil, fmt.Errorf("power: result exceeds %d bits", maxResultBits)
+		}
+		value.Exp(x, y, nil)
+	default:
+		return nil, fmt.Errorf("unknown operation: %s", op)
+	}
+	return integerResult(value, exact), nil
+}
+
+// calculateIntegerOnly 计算 gcd、lcm、位运算和移位，x 和 y 必须是整数
+func calculateIntegerOnly(op string, args map[string]any, precision string) (*CalculatorResult, error) {
+	x, err := parseInt("x", args["x"])
+	if err != nil {
+		return nil, fmt.Errorf("%s requires integers: %w", op, err)
+	}
+	y, err := parseInt("y", args["y"])
+	if err != nil {
+		return nil, fmt.Errorf("%s requires integers: %w", op, err)
+	}
+
+	value := new(big.Int)
+	switch op {
+	case "gcd":
+		value.GCD(nil, nil, new(big.Int).Abs(x), new(big.Int).Abs(y))
+	case "lcm":
+		// lcm(x, 0) = 0
+		if x.Sign() != 0 && y.Sign() != 0 {
+			gcd := new(big.Int).GCD(nil, nil, new(big.Int).Abs(x), new(big.Int).Abs(y))
+			value.Mul(x, y).Abs(value).Quo(value, gcd)
+		}
+	case "and":
+		value.And(x, y)
+	case "or":
+		value.Or(x, y)
+	case "xor":
+		value.Xor(x, y)
+	case "shift_left", "shift_right":
+		if y.Sign() < 0 || !y.IsInt64() || y.Int64() > maxResultBits {
+			return nil, fmt.Errorf("%s: y must be a shift count between 0 and %d", op, maxResultBits)
+		}
+		if op == "shift_left" {
+			if int64(x.BitLen())+y.Int64() > maxResultBits {
+				return nil, fmt.Errorf("%s: result exceeds %d bits", op, maxResultBits)
+			}
+			value.Lsh(x, uint(y.Int64()))
+		} else {
+			// 负数算术右移，向负无穷取整
+			value.Rsh(x, uint(y.Int64()))
+		}
+	default:
+		return nil, fmt.Errorf("unknown operation: %s", op)
+	}
+
+	// float 精度下结果需要能被 float64 精确表示
+	if precision == PrecisionFloat && new(big.Int).Abs(value).Cmp(maxExactFloatInt) > 0 {
+		return nil, fmt.Errorf("%s: result %s overflows the exact float64 integer range, use the integer precision", op, value)
+	}
+	result := integerResult(value, true)
+	if precision == PrecisionRational {
+		result.Numerator = value.String()
+		result.Denominator = "1"
+	}
+	return result, nil
+}
 
-	return mcp.NewToolResultText(fmt.Sprintf("%.2f", result)), nil
+// integerResult 创建整数结果
+func integerResult(value *big.Int, exact bool) *CalculatorResult {
+	f, _ := new(big.Float).SetInt(value).Float64()
+	return &CalculatorResult{Result: value.String(), Exact: exact, Value: f}
 }
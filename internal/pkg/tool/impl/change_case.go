@@ -0,0 +1,60 @@
+// Package impl change_case.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/textutil"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ChangeCaseTool 大小写和命名风格转换工具
+type ChangeCaseTool struct {
+	tool.BaseTool
+}
+
+// NewChangeCaseTool 创建大小写转换工具
+func NewChangeCaseTool() tool.Handler {
+	description := "Convert text to upper, lower, title or sentence case, or to an identifier style such as camelCase, snake_case or kebab-case"
+	changeCaseTool := mcp.NewTool("change_case",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The text to convert"),
+		),
+		mcp.WithString("case",
+			mcp.Required(),
+			mcp.Description("Target case; camel, pascal, snake, kebab and constant split words on spaces, punctuation and case changes"),
+			mcp.Enum(textutil.Cases...),
+		),
+		mcp.WithString("language",
+			mcp.Description("BCP 47 language tag for language-specific rules, e.g. tr for the Turkish dotted i"),
+		),
+	)
+
+	return &ChangeCaseTool{
+		BaseTool: tool.NewBaseTool(
+			"change_case",
+			description,
+			changeCaseTool),
+	}
+}
+
+// Handle 按指定风格转换文本
+func (c *ChangeCaseTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	style, err := request.RequireString("case")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := textutil.ConvertCase(text, style, request.GetString("language", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(result), nil
+}
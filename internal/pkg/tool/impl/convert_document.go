@@ -0,0 +1,88 @@
+// Package impl convert_document.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/document"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ConvertDocumentTool 文档格式转换和格式化工具
+type ConvertDocumentTool struct {
+	tool.BaseTool
+}
+
+// ConvertDocumentResult 文档转换的结构化结果
+type ConvertDocumentResult struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Output string `json:"output"`
+}
+
+// maxDocumentIndent 缩进空格数的上限
+const maxDocumentIndent = 8
+
+// NewConvertDocumentTool 创建文档转换工具
+func NewConvertDocumentTool() tool.Handler {
+	description := "Convert a document between JSON, YAML and TOML, or pretty-print and minify it by converting to the same format. " +
+		"Key order is kept except for TOML input and output, whose keys are sorted"
+	convertTool := mcp.NewTool("convert_document",
+		mcp.WithDescription(description),
+		documentParam("document", "The document to convert"),
+		formatParam("from", "Format of the document"),
+		mcp.WithString("to",
+			mcp.Description("Output format"),
+			mcp.Enum(document.Formats...),
+			mcp.DefaultString(document.JSON),
+		),
+		mcp.WithNumber("indent",
+			mcp.Description("Spaces per indentation level; 0 minifies JSON output onto one line"),
+			mcp.Min(0),
+			mcp.Max(maxDocumentIndent),
+			mcp.DefaultNumber(2),
+		),
+		mcp.WithBoolean("sort_keys",
+			mcp.Description("Sort object keys alphabetically"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithOutputSchema[ConvertDocumentResult](),
+	)
+
+	return &ConvertDocumentTool{
+		BaseTool: tool.NewBaseTool(
+			"convert_document",
+			description,
+			convertTool),
+	}
+}
+
+// Handle 解析文档并按目标格式输出
+func (c *ConvertDocumentTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	parsed, from, err := documentArg(request, "document", request.GetString("from", document.Auto))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	indent, err := optionalInt(request.GetArguments(), "indent", maxDocumentIndent)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if indent < 0 {
+		indent = 2
+	}
+	if request.GetBool("sort_keys", false) {
+		document.SortKeys(parsed)
+	}
+
+	to := request.GetString("to", document.JSON)
+	output, err := document.Encode(parsed, to, indent)
+	if err != nil {
+		return mcp.NewToolResultErrorf("cannot convert %s to %s: %v", from, to, err), nil
+	}
+	return mcp.NewToolResultStructured(ConvertDocumentResult{
+		From:   from,
+		To:     to,
+		Output: output,
+	}, output), nil
+}
@@ -0,0 +1,75 @@
+// Package impl convert_timezone.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ConvertTimezoneTool 时区转换工具
+type ConvertTimezoneTool struct {
+	tool.BaseTool
+}
+
+// ConvertTimezoneResult 时区转换的结构化结果
+type ConvertTimezoneResult struct {
+	Source TimeInfo `json:"source"`
+	Result TimeInfo `json:"result"`
+}
+
+// NewConvertTimezoneTool 创建时区转换工具
+func NewConvertTimezoneTool() tool.Handler {
+	description := "Convert a date and time from one time zone to another, taking daylight saving time into account"
+	convertTool := mcp.NewTool("convert_timezone",
+		mcp.WithDescription(description),
+		mcp.WithString("time",
+			mcp.Required(),
+			mcp.Description("The time to convert: "+dateInputDescription),
+		),
+		mcp.WithString("from",
+			mcp.Description("Time zone of the input when it has no offset, e.g. Europe/London"),
+			mcp.DefaultString("UTC"),
+		),
+		mcp.WithString("to",
+			mcp.Required(),
+			mcp.Description("Target time zone, e.g. Asia/Tokyo or +05:30"),
+		),
+		dayFirstParam(),
+		mcp.WithOutputSchema[ConvertTimezoneResult](),
+	)
+
+	return &ConvertTimezoneTool{
+		BaseTool: tool.NewBaseTool(
+			"convert_timezone",
+			description,
+			convertTool),
+	}
+}
+
+// Handle 按源时区解析时间并转换到目标时区
+func (c *ConvertTimezoneTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	from, err := locationArg(request, "from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if _, err := request.RequireString("to"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := locationArg(request, "to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	source, _, err := timeArg(request, "time", from)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	converted := source.In(to)
+	result := ConvertTimezoneResult{Source: newTimeInfo(source), Result: newTimeInfo(converted)}
+	text := fmt.Sprintf("%s %s = %s %s", source.Format("2006-01-02 15:04:05"), source.Location(),
+		converted.Format("2006-01-02 15:04:05"), converted.Location())
+	return mcp.NewToolResultStructured(result, text), nil
+}
@@ -0,0 +1,107 @@
+// Package impl convert_units.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"mcp-go-tutorials/internal/pkg/units"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ConvertUnitsTool 单位换算工具
+type ConvertUnitsTool struct {
+	tool.BaseTool
+}
+
+// ConvertUnitsResult 单位换算的结构化结果
+type ConvertUnitsResult struct {
+	Value    float64 `json:"value"`
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Result   float64 `json:"result"`
+	Quantity string  `json:"quantity"`
+	// Factor 1 个 from 单位等于多少个 to 单位，温度等带偏移的换算没有固定倍数，此时省略
+	Factor float64 `json:"factor,omitempty"`
+}
+
+// NewConvertUnitsTool 创建单位换算工具
+func NewConvertUnitsTool() tool.Handler {
+	var groups []string
+	for _, g := range units.Catalog() {
+		groups = append(groups, g.Quantity+" ("+strings.Join(g.Symbols, ", ")+")")
+	}
+	description := "Convert a value between units of the same dimension. Units: " + strings.Join(groups, "; ") +
+		". SI prefixes such as k, M, m and µ apply to m, L, g, s, Hz and K; " +
+		"decimal and binary prefixes such as k, M, Ki and Mi apply to bit and B. " +
+		"Compound units combine units with * and /, with ^n for exponents, e.g. km/h, m/s^2 or kg/m^3."
+	convertTool := mcp.NewTool("convert_units",
+		mcp.WithDescription(description),
+		mcp.WithNumber("value",
+			mcp.Required(),
+			mcp.Description("The value to convert"),
+		),
+		mcp.WithString("from",
+			mcp.Required(),
+			mcp.Description("The unit of value, e.g. km/h, °F or MiB"),
+			mcp.MaxLength(units.MaxLength),
+		),
+		mcp.WithString("to",
+			mcp.Required(),
+			mcp.Description("The unit to convert to, it must have the same dimension as from"),
+			mcp.MaxLength(units.MaxLength),
+		),
+		mcp.WithOutputSchema[ConvertUnitsResult](),
+	)
+
+	return &ConvertUnitsTool{
+		BaseTool: tool.NewBaseTool(
+			"convert_units",
+			description,
+			convertTool),
+	}
+}
+
+// Handle 解析两个单位，检查量纲后换算
+func (c *ConvertUnitsTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	value, err := request.RequireFloat("value")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	fromName, err := request.RequireString("from")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	toName, err := request.RequireString("to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	from, err := units.Parse(fromName)
+	if err != nil {
+		return mcp.NewToolResultErrorf("from: %v", err), nil
+	}
+	to, err := units.Parse(toName)
+	if err != nil {
+		return mcp.NewToolResultErrorf("to: %v", err), nil
+	}
+	result, err := units.Convert(value, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	structured := ConvertUnitsResult{
+		Value:    value,
+		From:     fromName,
+		To:       toName,
+		Result:   result,
+		Quantity: from.Quantity(),
+	}
+	if ratio, ok := units.Ratio(from, to); ok {
+		structured.Factor = ratio
+	}
+	text := fmt.Sprintf("%s %s = %s %s", formatFloat(value), fromName, formatFloat(result), toName)
+	return mcp.NewToolResultStructured(structured, text), nil
+}
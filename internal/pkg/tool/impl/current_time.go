@@ -0,0 +1,61 @@
+// Package impl current_time.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/datetime"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CurrentTimeTool 当前时间工具
+type CurrentTimeTool struct {
+	tool.BaseTool
+}
+
+// CurrentTimeResult 当前时间的结构化结果
+type CurrentTimeResult struct {
+	TimeInfo
+	Formatted string `json:"formatted,omitempty"` // 按 format 参数格式化的结果
+}
+
+// NewCurrentTimeTool 创建当前时间工具
+func NewCurrentTimeTool() tool.Handler {
+	description := "Get the current date and time in an IANA time zone"
+	currentTimeTool := mcp.NewTool("current_time",
+		mcp.WithDescription(description),
+		timezoneParam("Time zone to report the time in"),
+		mcp.WithString("format",
+			mcp.Description("Optional output format, see format_date"),
+		),
+		mcp.WithOutputSchema[CurrentTimeResult](),
+	)
+
+	return &CurrentTimeTool{
+		BaseTool: tool.NewBaseTool(
+			"current_time",
+			description,
+			currentTimeTool),
+	}
+}
+
+// Handle 返回指定时区的当前时间
+func (c *CurrentTimeTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc, err := locationArg(request, "timezone")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	now := time.Now().In(loc)
+
+	result := CurrentTimeResult{TimeInfo: newTimeInfo(now)}
+	text := result.ISO8601
+	if format := request.GetString("format", ""); format != "" {
+		if result.Formatted, err = datetime.Format(now, format); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		text = result.Formatted
+	}
+	return mcp.NewToolResultStructured(result, text), nil
+}
@@ -0,0 +1,147 @@
+// Package impl date_math.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/datetime"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DateMathTool 日期时长运算工具
+type DateMathTool struct {
+	tool.BaseTool
+}
+
+// DateMathResult 日期时长运算的结构化结果
+type DateMathResult struct {
+	Operation string       `json:"operation"`
+	Start     TimeInfo     `json:"start"`
+	Result    *TimeInfo    `json:"result,omitempty"` // add 和 subtract 的结果
+	End       *TimeInfo    `json:"end,omitempty"`    // diff 的结束时间
+	Duration  DurationInfo `json:"duration"`
+}
+
+// DurationInfo 时长的结构化表示
+type DurationInfo struct {
+	ISO8601 string  `json:"iso8601"`
+	Years   int     `json:"years"`
+	Months  int     `json:"months"`
+	Days    int     `json:"days"`
+	Hours   int     `json:"hours"`
+	Minutes int     `json:"minutes"`
+	Seconds float64 `json:"seconds"`
+	// TotalSeconds 和 TotalDays 为两个时刻之间实际经过的时间
+	TotalSeconds float64 `json:"total_seconds"`
+	TotalDays    float64 `json:"total_days"`
+}
+
+// NewDateMathTool 创建日期时长运算工具
+func NewDateMathTool() tool.Handler {
+	description := "Add a duration to a date, subtract a duration from it, or compute the difference between two dates. " +
+		"Years, months and days follow the calendar, so adding 1d across a daylight saving change keeps the wall clock time"
+	dateMathTool := mcp.NewTool("date_math",
+		mcp.WithDescription(description),
+		mcp.WithString("operation",
+			mcp.Required(),
+			mcp.Description("add and subtract apply duration to time; diff returns the duration from time to end"),
+			mcp.Enum("add", "subtract", "diff"),
+		),
+		mcp.WithString("time",
+			mcp.Required(),
+			mcp.Description("The start time: "+dateInputDescription),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Duration for add and subtract, in ISO 8601 (P1Y2M3DT4H) or unit form (1y2mo3w4d5h6m7s, 90m, 1.5h)"),
+		),
+		mcp.WithString("end",
+			mcp.Description("End time for diff"),
+		),
+		timezoneParam("Time zone for the calculation and for inputs without an offset"),
+		dayFirstParam(),
+		mcp.WithOutputSchema[DateMathResult](),
+	)
+
+	return &DateMathTool{
+		BaseTool: tool.NewBaseTool(
+			"date_math",
+			description,
+			dateMathTool),
+	}
+}
+
+// Handle 按 operation 计算
+func (d *DateMathTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	op, err := request.RequireString("operation")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	loc, err := locationArg(request, "timezone")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	start, _, err := timeArg(request, "time", loc)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := DateMathResult{Operation: op, Start: newTimeInfo(start)}
+	var end time.Time
+	switch op {
+	case "add", "subtract":
+		value, err := request.RequireString("duration")
+		if err != nil {
+			return mcp.NewToolResultErrorf("duration is required for %s", op), nil
+		}
+		duration, err := datetime.ParseDuration(value)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if op == "subtract" {
+			duration = duration.Neg()
+		}
+		end = duration.AddTo(start)
+		if end.Year() < 1 || end.Year() > 9999 {
+			return mcp.NewToolResultErrorf("the result %d is outside the years 1 to 9999", end.Year()), nil
+		}
+		info := newTimeInfo(end)
+		result.Result = &info
+		result.Duration = newDurationInfo(duration, start, end)
+	case "diff":
+		if _, err := request.RequireString("end"); err != nil {
+			return mcp.NewToolResultError("end is required for diff"), nil
+		}
+		if end, _, err = timeArg(request, "end", loc); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		info := newTimeInfo(end)
+		result.End = &info
+		result.Duration = newDurationInfo(datetime.Between(start, end), start, end)
+	default:
+		return mcp.NewToolResultErrorf("unknown operation %q, must be one of add, subtract, diff", op), nil
+	}
+
+	text := result.Duration.ISO8601
+	if result.Result != nil {
+		text = result.Result.ISO8601
+	}
+	return mcp.NewToolResultStructured(result, text), nil
+}
+
+// newDurationInfo 创建时长的结构化表示，start 和 end 用于计算实际经过的时间
+func newDurationInfo(d datetime.Duration, start, end time.Time) DurationInfo {
+	elapsed := float64(end.Unix()-start.Unix()) + float64(end.Nanosecond()-start.Nanosecond())/1e9
+	return DurationInfo{
+		ISO8601:      d.String(),
+		Years:        d.Years,
+		Months:       d.Months,
+		Days:         d.Days,
+		Hours:        int(d.Clock / time.Hour),
+		Minutes:      int(d.Clock % time.Hour / time.Minute),
+		Seconds:      (d.Clock % time.Minute).Seconds(),
+		TotalSeconds: elapsed,
+		TotalDays:    elapsed / 86400,
+	}
+}
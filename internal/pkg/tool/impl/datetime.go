@@ -0,0 +1,91 @@
+// Package impl datetime.go
+package impl
+
+import (
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/datetime"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TimeInfo 日期时间工具共用的时间结构化表示
+type TimeInfo struct {
+	ISO8601      string `json:"iso8601"`
+	Date         string `json:"date"`
+	Time         string `json:"time"`
+	Unix         int64  `json:"unix"`
+	UnixMilli    int64  `json:"unix_ms"`
+	Timezone     string `json:"timezone"`
+	Abbreviation string `json:"abbreviation"`
+	UTCOffset    string `json:"utc_offset"`
+	Weekday      string `json:"weekday"`
+	DayOfYear    int    `json:"day_of_year"`
+	ISOWeek      int    `json:"iso_week"`
+	DST          bool   `json:"dst"`
+}
+
+// newTimeInfo 创建时间的结构化表示
+func newTimeInfo(t time.Time) TimeInfo {
+	abbreviation, offset := t.Zone()
+	_, week := t.ISOWeek()
+	return TimeInfo{
+		ISO8601:      t.Format(time.RFC3339Nano),
+		Date:         t.Format(time.DateOnly),
+		Time:         t.Format(time.TimeOnly),
+		Unix:         t.Unix(),
+		UnixMilli:    t.UnixMilli(),
+		Timezone:     t.Location().String(),
+		Abbreviation: abbreviation,
+		UTCOffset:    datetime.FormatOffset(offset),
+		Weekday:      t.Weekday().String(),
+		DayOfYear:    t.YearDay(),
+		ISOWeek:      week,
+		DST:          t.IsDST(),
+	}
+}
+
+// timezoneParam timezone 参数，description 说明它在当前工具中的用途
+func timezoneParam(description string) mcp.ToolOption {
+	return mcp.WithString("timezone",
+		mcp.Description(description+"; an IANA name such as America/New_York, UTC, or an offset such as +08:00"),
+		mcp.DefaultString("UTC"),
+	)
+}
+
+// dayFirstParam day_first 参数
+func dayFirstParam() mcp.ToolOption {
+	return mcp.WithBoolean("day_first",
+		mcp.Description("Read ambiguous numeric dates such as 03/04/2025 as day/month/year instead of month/day/year"),
+		mcp.DefaultBool(false),
+	)
+}
+
+// dateInputDescription 日期参数的说明
+const dateInputDescription = "ISO 8601 / RFC 3339, RFC 1123, formats such as 2006-01-02 15:04, Jan 2, 2006 or 01/02/2006, " +
+	"a Unix timestamp in seconds or milliseconds, or now, today, tomorrow and yesterday"
+
+// locationArg 读取时区参数
+func locationArg(request mcp.CallToolRequest, name string) (*time.Location, error) {
+	loc, err := datetime.LoadLocation(request.GetString(name, "UTC"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return loc, nil
+}
+
+// timeArg 读取并解析日期参数，没有时区的输入按 loc 解释，结果转换到 loc
+func timeArg(request mcp.CallToolRequest, name string, loc *time.Location) (time.Time, datetime.Parsed, error) {
+	value, err := request.RequireString(name)
+	if err != nil {
+		return time.Time{}, datetime.Parsed{}, err
+	}
+	parsed, err := datetime.Parse(value, datetime.ParseOptions{
+		Location: loc,
+		DayFirst: request.GetBool("day_first", false),
+	})
+	if err != nil {
+		return time.Time{}, parsed, fmt.Errorf("%s: %w", name, err)
+	}
+	return parsed.Time.In(loc), parsed, nil
+}
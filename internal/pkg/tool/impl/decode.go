@@ -0,0 +1,80 @@
+// Package impl decode.go
+package impl
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/codec"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DecodeTool 文本解码工具
+type DecodeTool struct {
+	tool.BaseTool
+}
+
+// DecodeResult 解码的结构化结果，二进制数据以 base64 返回
+type DecodeResult struct {
+	Encoding string `json:"encoding"`
+	Text     string `json:"text,omitempty"`
+	Base64   string `json:"base64,omitempty"`
+	Binary   bool   `json:"binary"`
+	Bytes    int    `json:"bytes"`
+}
+
+// NewDecodeTool 创建解码工具
+func NewDecodeTool() tool.Handler {
+	description := "Decode base64, base64url, base32, hex, URL query or percent-encoded text; results that are not valid UTF-8 text are returned as base64"
+	decodeTool := mcp.NewTool("decode",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The encoded text; whitespace and missing padding are accepted for base64, base32 and hex"),
+		),
+		mcp.WithString("encoding",
+			mcp.Required(),
+			mcp.Description("Encoding of the text"),
+			mcp.Enum(codec.Encodings...),
+		),
+		mcp.WithOutputSchema[DecodeResult](),
+	)
+
+	return &DecodeTool{
+		BaseTool: tool.NewBaseTool(
+			"decode",
+			description,
+			decodeTool),
+	}
+}
+
+// Handle 解码文本
+func (d *DecodeTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(text) > maxBinaryTextBytes {
+		return mcp.NewToolResultErrorf("text is %d bytes, the limit is %d", len(text), maxBinaryTextBytes), nil
+	}
+	encoding, err := request.RequireString("encoding")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	data, err := codec.Decode(encoding, text)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result := DecodeResult{Encoding: encoding, Bytes: len(data)}
+	if isBinary(data) {
+		result.Binary = true
+		result.Base64 = base64.StdEncoding.EncodeToString(data)
+		return mcp.NewToolResultStructured(result,
+			fmt.Sprintf("%d bytes of binary data (base64): %s", len(data), result.Base64)), nil
+	}
+	result.Text = string(data)
+	return mcp.NewToolResultStructured(result, result.Text), nil
+}
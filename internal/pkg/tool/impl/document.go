@@ -0,0 +1,76 @@
+// Package impl document.go
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/document"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxDocumentBytes 文档参数的最大字节数
+const maxDocumentBytes = 4 << 20
+
+// DocumentError 文档解析错误的结构化表示，行列号从 1 开始，未知时省略
+type DocumentError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// newDocumentError 创建文档解析错误的结构化表示
+func newDocumentError(err error) *DocumentError {
+	var syntaxErr *document.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &DocumentError{Message: syntaxErr.Msg, Line: syntaxErr.Line, Column: syntaxErr.Column}
+	}
+	return &DocumentError{Message: err.Error()}
+}
+
+// textOrValue 允许参数是文本形式的文档，也可以直接是 JSON 对象或数组
+func textOrValue() func(map[string]any) {
+	return func(schema map[string]any) {
+		schema["type"] = []string{"string", "object", "array"}
+	}
+}
+
+// documentParam 文档参数
+func documentParam(name, description string) mcp.ToolOption {
+	return mcp.WithString(name,
+		mcp.Required(),
+		mcp.Description(description+"; JSON, YAML or TOML text up to 4 MiB, or a JSON object or array"),
+		textOrValue(),
+	)
+}
+
+// formatParam 文档格式参数
+func formatParam(name, description string) mcp.ToolOption {
+	return mcp.WithString(name,
+		mcp.Description(description+"; auto tries JSON, then TOML, then YAML"),
+		mcp.Enum(append([]string{document.Auto}, document.Formats...)...),
+		mcp.DefaultString(document.Auto),
+	)
+}
+
+// documentArg 读取并按 format 解析文档参数，返回解析结果和实际格式
+func documentArg(request mcp.CallToolRequest, name, format string) (any, string, error) {
+	value, ok := request.GetArguments()[name]
+	if !ok || value == nil {
+		return nil, "", fmt.Errorf("required argument %q not found", name)
+	}
+	text, isText := value.(string)
+	if !isText {
+		// 客户端直接传入了 JSON 值
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", name, err)
+		}
+		text, format = string(data), document.JSON
+	}
+	if len(text) > maxDocumentBytes {
+		return nil, "", fmt.Errorf("%s is %d bytes, the limit is %d", name, len(text), maxDocumentBytes)
+	}
+	return document.Parse([]byte(text), format)
+}
@@ -0,0 +1,70 @@
+// Package impl encode.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/codec"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// EncodeTool 文本编码工具
+type EncodeTool struct {
+	tool.BaseTool
+}
+
+// EncodeResult 编码的结构化结果
+type EncodeResult struct {
+	Encoding   string `json:"encoding"`
+	Output     string `json:"output"`
+	InputBytes int    `json:"input_bytes"`
+}
+
+// NewEncodeTool 创建编码工具
+func NewEncodeTool() tool.Handler {
+	description := "Encode text or bytes as base64, base64url, base32, hex, URL query encoding or RFC 3986 percent-encoding"
+	encodeTool := mcp.NewTool("encode",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The data to encode, at most 1 MiB"),
+		),
+		inputEncodingParam("input_encoding", "text"),
+		mcp.WithString("encoding",
+			mcp.Required(),
+			mcp.Description("Target encoding; url encodes spaces as +, percent encodes everything except letters, digits and -._~"),
+			mcp.Enum(codec.Encodings...),
+		),
+		mcp.WithOutputSchema[EncodeResult](),
+	)
+
+	return &EncodeTool{
+		BaseTool: tool.NewBaseTool(
+			"encode",
+			description,
+			encodeTool),
+	}
+}
+
+// Handle 编码数据
+func (e *EncodeTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	data, err := binaryArg(request, "text", "input_encoding", false)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	encoding, err := request.RequireString("encoding")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	output, err := codec.Encode(encoding, data)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultStructured(EncodeResult{
+		Encoding:   encoding,
+		Output:     output,
+		InputBytes: len(data),
+	}, output), nil
+}
@@ -0,0 +1,139 @@
+// Package impl evaluate.go
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/expr"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxExpressionBytes 表达式的最大字节数
+const maxExpressionBytes = 4096
+
+// EvaluateTool 表达式求值工具
+type EvaluateTool struct {
+	tool.BaseTool
+}
+
+// EvaluateResult 表达式求值的结构化结果
+type EvaluateResult struct {
+	Expression string             `json:"expression"`
+	Result     float64            `json:"result"`
+	Variables  map[string]float64 `json:"variables,omitempty"`
+}
+
+// NewEvaluateTool 创建表达式求值工具
+func NewEvaluateTool() tool.Handler {
+	description := "Evaluate an arithmetic expression such as (3+4)*sqrt(2) in one call. " +
+		"Supports + - * / % and ^ (or **) with the usual precedence, parentheses, variables, " +
+		"the constants " + strings.Join(expr.Constants(), ", ") +
+		" and the functions " + strings.Join(expr.Functions(), ", ") + "."
+	evaluateTool := mcp.NewTool("evaluate",
+		mcp.WithDescription(description),
+		mcp.WithString("expression",
+			mcp.Required(),
+			mcp.Description("The expression to evaluate, e.g. 2*pi*r^2 or log(100, 10)"),
+			mcp.MaxLength(maxExpressionBytes),
+		),
+		mcp.WithObject("variables",
+			mcp.Description("Values for the variables used in the expression, e.g. {\"r\": 1.5}"),
+			mcp.AdditionalProperties(map[string]any{"type": "number"}),
+		),
+		mcp.WithString("angle_unit",
+			mcp.Description("Unit for trigonometric functions"),
+			mcp.Enum("radians", "degrees"),
+			mcp.DefaultString("radians"),
+		),
+		mcp.WithOutputSchema[EvaluateResult](),
+	)
+
+	return &EvaluateTool{
+		BaseTool: tool.NewBaseTool(
+			"evaluate",
+			description,
+			evaluateTool),
+	}
+}
+
+// Handle 解析并计算表达式，解析错误会指出出错的位置
+func (e *EvaluateTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	source, err := request.RequireString("expression")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(source) > maxExpressionBytes {
+		return mcp.NewToolResultErrorf("expression is %d bytes, the limit is %d", len(source), maxExpressionBytes), nil
+	}
+	vars, err := numberMap(request.GetArguments()["variables"])
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	for name := range vars {
+		if expr.IsReserved(name) {
+			return mcp.NewToolResultErrorf("variable %s conflicts with a built-in constant or function", name), nil
+		}
+	}
+
+	parsed, err := expr.Parse(source)
+	if err != nil {
+		return expressionError("parse error", source, err), nil
+	}
+	env := expr.Env{
+		Vars:    vars,
+		Degrees: request.GetString("angle_unit", "radians") == "degrees",
+	}
+	result, err := parsed.Eval(env)
+	if err != nil {
+		return expressionError("evaluation error", source, err), nil
+	}
+
+	return mcp.NewToolResultStructured(EvaluateResult{
+		Expression: source,
+		Result:     result,
+		Variables:  vars,
+	}, strconv.FormatFloat(result, 'g', -1, 64)), nil
+}
+
+// numberMap 将 JSON 对象转换为变量表，值必须是数字
+func numberMap(value any) (map[string]float64, error) {
+	if value == nil {
+		return nil, nil
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("variables must be an object")
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	vars := make(map[string]float64, len(object))
+	for _, name := range names {
+		number, ok := object[name].(float64)
+		if !ok {
+			return nil, fmt.Errorf("variables.%s must be a number, got %v", name, object[name])
+		}
+		vars[name] = number
+	}
+	return vars, nil
+}
+
+// expressionError 返回带有出错位置标记的错误结果
+func expressionError(kind string, source string, err error) *mcp.CallToolResult {
+	var exprErr *expr.Error
+	if !errors.As(err, &exprErr) {
+		return mcp.NewToolResultErrorf("%s: %v", kind, err)
+	}
+	// 在表达式下方用 ^ 标出出错的字符
+	marker := strings.Repeat(" ", exprErr.Pos-1) + "^"
+	return mcp.NewToolResultErrorf("%s at position %d: %s\n%s\n%s", kind, exprErr.Pos, exprErr.Msg, source, marker)
+}
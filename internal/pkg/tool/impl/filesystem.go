@@ -0,0 +1,64 @@
+// Package impl filesystem.go
+package impl
+
+import (
+	"io/fs"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FileInfo 文件系统工具共用的文件信息
+type FileInfo struct {
+	Path     string `json:"path"`
+	Name     string `json:"name"`
+	Type     string `json:"type"` // file、directory、symlink 或 other
+	Size     int64  `json:"size"`
+	Mode     string `json:"mode"`
+	Modified string `json:"modified"`
+}
+
+// newFileInfo 创建文件信息
+func newFileInfo(p sandbox.Path, info fs.FileInfo) FileInfo {
+	return FileInfo{
+		Path:     p.String(),
+		Name:     info.Name(),
+		Type:     fileType(info.Mode()),
+		Size:     info.Size(),
+		Mode:     info.Mode().String(),
+		Modified: info.ModTime().UTC().Format(time.RFC3339),
+	}
+}
+
+// fileType 返回文件类型的名称
+func fileType(mode fs.FileMode) string {
+	switch {
+	case mode.IsDir():
+		return "directory"
+	case mode&fs.ModeSymlink != 0:
+		return "symlink"
+	case mode.IsRegular():
+		return "file"
+	default:
+		return "other"
+	}
+}
+
+// pathParam 路径参数，description 说明它在当前工具中的用途
+func pathParam(name, description string, fsys *sandbox.FS, required bool) mcp.ToolOption {
+	options := []mcp.PropertyOption{
+		mcp.Description(description + "; an absolute path inside " + strings.Join(fsys.Roots(), ", ") +
+			" or a path relative to the first of them"),
+	}
+	if required {
+		options = append(options, mcp.Required())
+	}
+	return mcp.WithString(name, options...)
+}
+
+// isHidden 名称以 . 开头的文件和目录视为隐藏
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".") && name != "." && name != ".."
+}
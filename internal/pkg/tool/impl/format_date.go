@@ -0,0 +1,71 @@
+// Package impl format_date.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/datetime"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FormatDateTool 日期格式化工具
+type FormatDateTool struct {
+	tool.BaseTool
+}
+
+// FormatDateResult 日期格式化的结构化结果
+type FormatDateResult struct {
+	Formatted string   `json:"formatted"`
+	Time      TimeInfo `json:"time"`
+}
+
+// NewFormatDateTool 创建日期格式化工具
+func NewFormatDateTool() tool.Handler {
+	description := "Format a date and time using a named format, a strftime pattern or a Go layout"
+	formatTool := mcp.NewTool("format_date",
+		mcp.WithDescription(description),
+		mcp.WithString("time",
+			mcp.Required(),
+			mcp.Description("The time to format: "+dateInputDescription),
+		),
+		mcp.WithString("format",
+			mcp.Required(),
+			mcp.Description("One of "+strings.Join(datetime.FormatNames(), ", ")+
+				"; a strftime pattern such as %Y-%m-%d %H:%M:%S or %A, %B %e; or a Go layout such as Mon Jan 2 15:04"),
+		),
+		timezoneParam("Time zone to render the time in, also used for inputs without an offset"),
+		dayFirstParam(),
+		mcp.WithOutputSchema[FormatDateResult](),
+	)
+
+	return &FormatDateTool{
+		BaseTool: tool.NewBaseTool(
+			"format_date",
+			description,
+			formatTool),
+	}
+}
+
+// Handle 解析时间并按格式输出
+func (f *FormatDateTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	format, err := request.RequireString("format")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	loc, err := locationArg(request, "timezone")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	t, _, err := timeArg(request, "time", loc)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	formatted, err := datetime.Format(t, format)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultStructured(FormatDateResult{Formatted: formatted, Time: newTimeInfo(t)}, formatted), nil
+}
@@ -0,0 +1,112 @@
+// Package impl hash.go
+package impl
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"mcp-go-tutorials/internal/pkg/codec"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// HashTool 摘要和校验和工具
+type HashTool struct {
+	tool.BaseTool
+}
+
+// HashResult 摘要的结构化结果
+type HashResult struct {
+	Algorithm  string `json:"algorithm"`
+	HMAC       bool   `json:"hmac"`
+	Hex        string `json:"hex"`
+	Base64     string `json:"base64"`
+	InputBytes int    `json:"input_bytes"`
+	Matches    *bool  `json:"matches,omitempty"` // 提供 expected 时是否与之相等
+}
+
+// NewHashTool 创建摘要工具
+func NewHashTool() tool.Handler {
+	description := "Compute the MD5, SHA-1, SHA-2 or CRC32/Adler-32 checksum of text or bytes, or an HMAC when a key is given, " +
+		"and optionally verify it against an expected digest"
+	hashTool := mcp.NewTool("hash",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The data to hash, at most 1 MiB"),
+		),
+		inputEncodingParam("input_encoding", "text"),
+		mcp.WithString("algorithm",
+			mcp.Description("Digest algorithm; crc32 uses the IEEE polynomial of zip and gzip"),
+			mcp.Enum(codec.Algorithms...),
+			mcp.DefaultString(codec.SHA256),
+		),
+		mcp.WithString("key",
+			mcp.Description("HMAC key; when given, an HMAC is computed instead of a plain digest"),
+		),
+		inputEncodingParam("key_encoding", "key"),
+		mcp.WithString("expected",
+			mcp.Description("Expected digest in hex or base64, compared in constant time"),
+		),
+		mcp.WithOutputSchema[HashResult](),
+	)
+
+	return &HashTool{
+		BaseTool: tool.NewBaseTool(
+			"hash",
+			description,
+			hashTool),
+	}
+}
+
+// Handle 计算摘要
+func (h *HashTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	data, err := binaryArg(request, "text", "input_encoding", false)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	key, err := binaryArg(request, "key", "key_encoding", true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	algorithm := request.GetString("algorithm", codec.SHA256)
+
+	sum, err := codec.Sum(algorithm, data, key)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result := HashResult{
+		Algorithm:  algorithm,
+		HMAC:       key != nil,
+		Hex:        hex.EncodeToString(sum),
+		Base64:     base64.StdEncoding.EncodeToString(sum),
+		InputBytes: len(data),
+	}
+	text := result.Hex
+	if expected := strings.TrimSpace(request.GetString("expected", "")); expected != "" {
+		matches := codec.Equal(sum, parseDigest(expected, len(sum)))
+		result.Matches = &matches
+		if matches {
+			text += " (matches expected)"
+		} else {
+			text += " (does NOT match expected)"
+		}
+	}
+	return mcp.NewToolResultStructured(result, text), nil
+}
+
+// parseDigest 按长度判断期望摘要是 hex 还是 base64，都无法解析时返回 nil
+func parseDigest(expected string, size int) []byte {
+	if len(expected) == 2*size {
+		if digest, err := hex.DecodeString(expected); err == nil {
+			return digest
+		}
+	}
+	digest, err := codec.Decode(codec.Base64, expected)
+	if err != nil {
+		return nil
+	}
+	return digest
+}
@@ -0,0 +1,116 @@
+// Package impl list_directory.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListDirectoryTool 列出目录工具
+type ListDirectoryTool struct {
+	tool.BaseTool
+	fsys *sandbox.FS
+}
+
+// ListDirectoryResult 列出目录的结构化结果
+type ListDirectoryResult struct {
+	Path      string     `json:"path"`
+	Entries   []FileInfo `json:"entries"`
+	Truncated bool       `json:"truncated,omitempty"` // 条目数达到上限
+}
+
+// 列出目录的限制
+const (
+	maxListEntries = 1000
+	maxListDepth   = 20
+)
+
+// NewListDirectoryTool 创建列出目录工具
+func NewListDirectoryTool(fsys *sandbox.FS) tool.Handler {
+	description := fmt.Sprintf("List the files and subdirectories of a directory in the allowed directories (%s), "+
+		"optionally recursively; at most %d entries are returned", strings.Join(fsys.Roots(), ", "), maxListEntries)
+	listTool := mcp.NewTool("list_directory",
+		mcp.WithDescription(description),
+		pathParam("path", "The directory to list, defaults to the first allowed directory", fsys, false),
+		mcp.WithNumber("depth",
+			mcp.Description("How many levels to list; 1 lists only the directory itself"),
+			mcp.Min(1),
+			mcp.Max(maxListDepth),
+			mcp.DefaultNumber(1),
+		),
+		mcp.WithBoolean("include_hidden",
+			mcp.Description("Include files and directories whose names start with a dot"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithOutputSchema[ListDirectoryResult](),
+	)
+
+	return &ListDirectoryTool{
+		BaseTool: tool.NewBaseTool(
+			"list_directory",
+			description,
+			listTool),
+		fsys: fsys,
+	}
+}
+
+// Handle 列出目录
+func (l *ListDirectoryTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	depth, err := optionalInt(request.GetArguments(), "depth", maxListDepth)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if depth <= 0 {
+		depth = 1
+	}
+	includeHidden := request.GetBool("include_hidden", false)
+
+	result := ListDirectoryResult{Entries: []FileInfo{}}
+	var lines []string
+	p, err := l.fsys.Walk(request.GetString("path", ""), depth, func(p sandbox.Path, entry fs.DirEntry, level int) error {
+		if !includeHidden && isHidden(entry.Name()) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if len(result.Entries) == maxListEntries {
+			result.Truncated = true
+			return fs.SkipAll
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		fileInfo := newFileInfo(p, info)
+		result.Entries = append(result.Entries, fileInfo)
+
+		line := strings.Repeat("  ", level-1) + entry.Name()
+		if entry.IsDir() {
+			line += "/"
+		} else if fileInfo.Type == "file" {
+			line += fmt.Sprintf(" (%d bytes)", fileInfo.Size)
+		}
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result.Path = p.String()
+	text := p.String() + "/\n" + strings.Join(lines, "\n")
+	if len(lines) == 0 {
+		text = p.String() + " is empty"
+	}
+	if result.Truncated {
+		text += fmt.Sprintf("\n... only the first %d entries are listed", maxListEntries)
+	}
+	return mcp.NewToolResultStructured(result, text), nil
+}
@@ -0,0 +1,68 @@
+// Package impl move.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MoveTool 移动和重命名工具
+type MoveTool struct {
+	tool.BaseTool
+	fsys *sandbox.FS
+}
+
+// MoveResult 移动的结构化结果
+type MoveResult struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// NewMoveTool 创建移动工具
+func NewMoveTool(fsys *sandbox.FS) tool.Handler {
+	description := fmt.Sprintf("Move or rename a file or directory within the allowed directories (%s)", strings.Join(fsys.Roots(), ", "))
+	moveTool := mcp.NewTool("move",
+		mcp.WithDescription(description),
+		pathParam("source", "The file or directory to move", fsys, true),
+		pathParam("destination", "The new path, including the file name; its directory must exist", fsys, true),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace the destination if it is an existing file"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithOutputSchema[MoveResult](),
+	)
+
+	return &MoveTool{
+		BaseTool: tool.NewBaseTool(
+			"move",
+			description,
+			moveTool),
+		fsys: fsys,
+	}
+}
+
+// Handle 移动文件或目录
+func (m *MoveTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	source, err := request.RequireString("source")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	destination, err := request.RequireString("destination")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	src, dst, err := m.fsys.Move(source, destination, request.GetBool("overwrite", false))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultStructured(MoveResult{
+		Source:      src.String(),
+		Destination: dst.String(),
+	}, fmt.Sprintf("moved %s to %s", src, dst)), nil
+}
@@ -0,0 +1,80 @@
+// Package impl normalize_unicode.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeUnicodeTool Unicode 规范化工具
+type NormalizeUnicodeTool struct {
+	tool.BaseTool
+}
+
+// NormalizeUnicodeResult Unicode 规范化的结构化结果
+type NormalizeUnicodeResult struct {
+	Text    string `json:"text"`
+	Form    string `json:"form"`
+	Changed bool   `json:"changed"` // 输入是否已经是该规范形式
+	Runes   int    `json:"runes"`   // 规范化后的码点数
+}
+
+// 支持的规范化形式
+var normalizationForms = map[string]norm.Form{
+	"NFC":  norm.NFC,
+	"NFD":  norm.NFD,
+	"NFKC": norm.NFKC,
+	"NFKD": norm.NFKD,
+}
+
+// NewNormalizeUnicodeTool 创建 Unicode 规范化工具
+func NewNormalizeUnicodeTool() tool.Handler {
+	description := "Normalize Unicode text to NFC, NFD, NFKC or NFKD so that visually identical strings compare equal"
+	normalizeTool := mcp.NewTool("normalize_unicode",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The text to normalize"),
+		),
+		mcp.WithString("form",
+			mcp.Description("NFC composes characters (e + ◌́ → é); NFD decomposes them; "+
+				"NFKC and NFKD also replace compatibility characters such as ﬁ → fi and ① → 1"),
+			mcp.Enum("NFC", "NFD", "NFKC", "NFKD"),
+			mcp.DefaultString("NFC"),
+		),
+		mcp.WithOutputSchema[NormalizeUnicodeResult](),
+	)
+
+	return &NormalizeUnicodeTool{
+		BaseTool: tool.NewBaseTool(
+			"normalize_unicode",
+			description,
+			normalizeTool),
+	}
+}
+
+// Handle 按指定形式规范化文本
+func (n *NormalizeUnicodeTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name := strings.ToUpper(request.GetString("form", "NFC"))
+	form, ok := normalizationForms[name]
+	if !ok {
+		return mcp.NewToolResultErrorf("unknown form %q, must be one of NFC, NFD, NFKC, NFKD", name), nil
+	}
+
+	normalized := form.String(text)
+	return mcp.NewToolResultStructured(NormalizeUnicodeResult{
+		Text:    normalized,
+		Form:    name,
+		Changed: normalized != text,
+		Runes:   utf8.RuneCountInString(normalized),
+	}, normalized), nil
+}
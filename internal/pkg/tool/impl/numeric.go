@@ -0,0 +1,205 @@
+// Package impl numeric.go
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// 计算精度
+const (
+	PrecisionFloat    = "float"    // float64，速度快但有二进制舍入误差
+	PrecisionDecimal  = "decimal"  // 精确的十进制小数，无限小数按 scale 舍入
+	PrecisionRational = "rational" // 精确的分数
+	PrecisionInteger  = "integer"  // 任意大小的整数
+)
+
+// 舍入方式
+const (
+	RoundHalfUp   = "half_up"   // 四舍五入，.5 远离 0
+	RoundHalfDown = "half_down" // .5 趋向 0
+	RoundHalfEven = "half_even" // 银行家舍入，.5 舍入到偶数
+	RoundUp       = "up"        // 远离 0
+	RoundDown     = "down"      // 趋向 0，即截断
+	RoundCeiling  = "ceiling"   // 趋向正无穷
+	RoundFloor    = "floor"     // 趋向负无穷
+)
+
+var (
+	precisions    = []string{PrecisionFloat, PrecisionDecimal, PrecisionRational, PrecisionInteger}
+	roundingModes = []string{RoundHalfUp, RoundHalfDown, RoundHalfEven, RoundUp, RoundDown, RoundCeiling, RoundFloor}
+)
+
+// 输出小数位数的上限，以及 decimal 精度下无限小数的默认小数位数
+const (
+	maxScale            = 100
+	defaultDecimalScale = 20
+)
+
+// numberOrString 允许参数是 JSON 数字或字符串，字符串形式可以无损传递大整数和十进制小数
+func numberOrString() func(map[string]any) {
+	return func(schema map[string]any) {
+		schema["type"] = []string{"number", "string"}
+	}
+}
+
+// parseRat 将 JSON 数字或字符串转换为有理数，字符串支持整数、小数、科学计数法和 a/b 形式的分数
+// JSON 数字使用最短的十进制表示，因此 0.1 会被当作精确的 1/10
+func parseRat(name string, value any) (*big.Rat, error) {
+	switch v := value.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("%s must be a finite number", name)
+		}
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+		return r, nil
+	case string:
+		r, ok := new(big.Rat).SetString(strings.TrimSpace(v))
+		if !ok {
+			return nil, fmt.Errorf("%s: %q is not a number", name, v)
+		}
+		// 1e999999 这样的输入解析后有数百万位，后续的运算和格式化都会很慢
+		if r.Num().BitLen() > maxResultBits || r.Denom().BitLen() > maxResultBits {
+			return nil, fmt.Errorf("%s exceeds %d bits", name, maxResultBits)
+		}
+		return r, nil
+	case nil:
+		return nil, fmt.Errorf("required argument %q not found", name)
+	default:
+		return nil, fmt.Errorf("%s must be a number or a numeric string, got %T", name, value)
+	}
+}
+
+// parseFloat 将 JSON 数字或字符串转换为 float64
+func parseFloat(name string, value any) (float64, error) {
+	if v, ok := value.(float64); ok {
+		return v, nil
+	}
+	r, err := parseRat(name, value)
+	if err != nil {
+		return 0, err
+	}
+	f, _ := r.Float64()
+	if math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%s is out of the float64 range", name)
+	}
+	return f, nil
+}
+
+// parseInt 将 JSON 数字或字符串转换为任意大小的整数，小数会被拒绝
+func parseInt(name string, value any) (*big.Int, error) {
+	r, err := parseRat(name, value)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%s must be an integer, got %v", name, value)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// optionalInt 读取可选的整数参数，未提供时返回 -1
+func optionalInt(args map[string]any, name string, maxValue int) (int, error) {
+	value, ok := args[name]
+	if !ok || value == nil {
+		return -1, nil
+	}
+	f, ok := value.(float64)
+	if !ok || f != math.Trunc(f) || f < 0 || f > float64(maxValue) {
+		return 0, fmt.Errorf("%s must be an integer between 0 and %d", name, maxValue)
+	}
+	return int(f), nil
+}
+
+// roundQuotient 按舍入方式将 num/den 舍入为整数
+func roundQuotient(num, den *big.Int, mode string) *big.Int {
+	q, m := new(big.Int).QuoRem(num, den, new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+	// 商向 0 截断，sign 为精确结果的符号
+	sign := num.Sign() * den.Sign()
+	// 比较余数的两倍和除数，判断是否超过一半
+	half := new(big.Int).Abs(m)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(new(big.Int).Abs(den))
+
+	var away bool
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = sign > 0
+	case RoundFloor:
+		away = sign < 0
+	case RoundHalfDown:
+		away = cmp > 0
+	case RoundHalfEven:
+		away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+	default:
+		away = cmp >= 0
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
+
+// roundRat 按舍入方式将 r 舍入到 scale 位小数
+func roundRat(r *big.Rat, scale int, mode string) *big.Rat {
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	num := new(big.Int).Mul(r.Num(), pow)
+	return new(big.Rat).SetFrac(roundQuotient(num, r.Denom(), mode), pow)
+}
+
+// decimalPlaces 返回 r 作为有限小数所需的小数位数，无限小数返回 false
+func decimalPlaces(r *big.Rat) (int, bool) {
+	den := new(big.Int).Set(r.Denom())
+	twos, fives := 0, 0
+	for den.Bit(0) == 0 {
+		den.Rsh(den, 1)
+		twos++
+	}
+	five := big.NewInt(5)
+	m := new(big.Int)
+	for {
+		q, r := new(big.Int).QuoRem(den, five, m)
+		if r.Sign() != 0 {
+			break
+		}
+		den = q
+		fives++
+	}
+	return max(twos, fives), den.Cmp(big.NewInt(1)) == 0
+}
+
+// formatDecimal 将 r 格式化为十进制小数，scale 小于 0 时有限小数原样输出、无限小数按默认位数舍入
+// 返回的 exact 表示输出是否与 r 相等
+func formatDecimal(r *big.Rat, scale int, mode string) (string, bool) {
+	if scale < 0 {
+		if places, ok := decimalPlaces(r); ok {
+			return r.FloatString(places), true
+		}
+		rounded := roundRat(r, defaultDecimalScale, mode)
+		return trimZeros(rounded.FloatString(defaultDecimalScale)), false
+	}
+	rounded := roundRat(r, scale, mode)
+	return rounded.FloatString(scale), rounded.Cmp(r) == 0
+}
+
+// trimZeros 去掉小数末尾的 0
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// errDivisionByZero 除数为 0
+var errDivisionByZero = errors.New("cannot divide by zero")
@@ -0,0 +1,77 @@
+// Package impl parse_date.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/datetime"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ParseDateTool 日期解析工具
+type ParseDateTool struct {
+	tool.BaseTool
+}
+
+// ParseDateResult 日期解析的结构化结果
+type ParseDateResult struct {
+	TimeInfo
+	Input  string `json:"input"`
+	Format string `json:"format"` // 匹配的输入格式
+	// Ambiguous 为 true 时，输入按月/日和日/月都能解析，结果取决于 day_first
+	Ambiguous bool `json:"ambiguous"`
+}
+
+// NewParseDateTool 创建日期解析工具
+func NewParseDateTool() tool.Handler {
+	description := "Parse a date or time written in one of many common formats and normalize it to ISO 8601"
+	parseDateTool := mcp.NewTool("parse_date",
+		mcp.WithDescription(description),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("The date to parse: "+dateInputDescription),
+		),
+		timezoneParam("Time zone for inputs without an offset; inputs with an offset keep it"),
+		dayFirstParam(),
+		mcp.WithOutputSchema[ParseDateResult](),
+	)
+
+	return &ParseDateTool{
+		BaseTool: tool.NewBaseTool(
+			"parse_date",
+			description,
+			parseDateTool),
+	}
+}
+
+// Handle 解析日期，输入带有时区或时差时保留原始时差
+func (p *ParseDateTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	value, err := request.RequireString("date")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	loc, err := locationArg(request, "timezone")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	parsed, err := datetime.Parse(value, datetime.ParseOptions{
+		Location: loc,
+		DayFirst: request.GetBool("day_first", false),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := ParseDateResult{
+		TimeInfo:  newTimeInfo(parsed.Time),
+		Input:     value,
+		Format:    parsed.Format,
+		Ambiguous: parsed.Ambiguous,
+	}
+	text := result.ISO8601
+	if parsed.Ambiguous {
+		text += " (ambiguous: set day_first to choose between month/day and day/month)"
+	}
+	return mcp.NewToolResultStructured(result, text), nil
+}
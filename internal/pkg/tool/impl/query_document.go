@@ -0,0 +1,118 @@
+// Package impl query_document.go
+package impl
+
+import (
+	"context"
+	"errors"
+	"mcp-go-tutorials/internal/pkg/document"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxPathBytes JSONPath 表达式的最大字节数
+const maxPathBytes = 4096
+
+// QueryDocumentTool JSONPath 查询工具
+type QueryDocumentTool struct {
+	tool.BaseTool
+}
+
+// QueryDocumentResult JSONPath 查询的结构化结果
+type QueryDocumentResult struct {
+	Path    string           `json:"path"`
+	Format  string           `json:"format"`
+	Count   int              `json:"count"`
+	Matches []document.Match `json:"matches"`
+}
+
+// NewQueryDocumentTool 创建 JSONPath 查询工具
+func NewQueryDocumentTool() tool.Handler {
+	description := "Extract values from a JSON, YAML or TOML document with a JSONPath expression, " +
+		"e.g. $.store.book[?@.price < 10].title, $..author or $.items[-1]; each match is returned with its normalized path"
+	queryTool := mcp.NewTool("query_document",
+		mcp.WithDescription(description),
+		documentParam("document", "The document to query"),
+		formatParam("format", "Format of the document"),
+		mcp.WithString("path",
+			mcp.Required(),
+			mcp.Description("JSONPath expression: $ is the root, .name or ['name'] a member, [0] or [-1] an element, "+
+				"[1:3] a slice, * all children, .. all descendants, [?@.age >= 18 && @.name =~ /^a/i] a filter with length(). "+
+				"jq-style .a.b and [] are accepted too"),
+			mcp.MaxLength(maxPathBytes),
+		),
+		mcp.WithOutputSchema[QueryDocumentResult](),
+	)
+
+	return &QueryDocumentTool{
+		BaseTool: tool.NewBaseTool(
+			"query_document",
+			description,
+			queryTool),
+	}
+}
+
+// Handle 解析文档并执行查询
+func (q *QueryDocumentTool) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	source, err := request.RequireString("path")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(source) > maxPathBytes {
+		return mcp.NewToolResultErrorf("path is %d bytes, the limit is %d", len(source), maxPathBytes), nil
+	}
+	path, err := document.CompilePath(source)
+	if err != nil {
+		return pathError(source, err), nil
+	}
+	parsed, format, err := documentArg(request, "document", request.GetString("format", document.Auto))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	matches, err := path.Query(ctx, parsed)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	values := make([]any, len(matches))
+	for i, match := range matches {
+		values[i] = match.Value
+	}
+	// 结构化结果以 JSON 传输，YAML 和 TOML 中的 .inf 等值无法表示
+	if err := document.CheckJSON(values); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// 只有一个结果时直接输出该值，否则输出数组
+	text := "no match"
+	switch len(matches) {
+	case 0:
+	case 1:
+		text, err = document.Encode(values[0], document.JSON, 2)
+	default:
+		text, err = document.Encode(values, document.JSON, 2)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if matches == nil {
+		matches = []document.Match{}
+	}
+	return mcp.NewToolResultStructured(QueryDocumentResult{
+		Path:    source,
+		Format:  format,
+		Count:   len(matches),
+		Matches: matches,
+	}, text), nil
+}
+
+// pathError 返回在表达式下方标出出错位置的错误
+func pathError(source string, err error) *mcp.CallToolResult {
+	var pathErr *document.PathError
+	if !errors.As(err, &pathErr) {
+		return mcp.NewToolResultError(err.Error())
+	}
+	marker := strings.Repeat(" ", pathErr.Pos-1) + "^"
+	return mcp.NewToolResultErrorf("%v\n%s\n%s", err, source, marker)
+}
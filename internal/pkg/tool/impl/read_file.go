@@ -0,0 +1,142 @@
+// Package impl read_file.go
+package impl
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ReadFileTool 读取文件工具
+type ReadFileTool struct {
+	tool.BaseTool
+	fsys *sandbox.FS
+}
+
+// ReadFileResult 读取文件的结构化结果
+type ReadFileResult struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`     // 文件的总字节数
+	Encoding  string `json:"encoding"` // text 或 base64
+	Binary    bool   `json:"binary"`
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated,omitempty"` // 文件超过读取上限，只返回了开头部分
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	Lines     int    `json:"lines,omitempty"` // 已读取部分的总行数
+}
+
+// NewReadFileTool 创建读取文件工具
+func NewReadFileTool(fsys *sandbox.FS) tool.Handler {
+	description := fmt.Sprintf("Read a file from the allowed directories (%s). Text is returned as is, "+
+		"binary files as base64; files larger than %d bytes are truncated", strings.Join(fsys.Roots(), ", "), fsys.MaxFileBytes())
+	readTool := mcp.NewTool("read_file",
+		mcp.WithDescription(description),
+		pathParam("path", "The file to read", fsys, true),
+		mcp.WithString("encoding",
+			mcp.Description("auto returns text files as text and binary files as base64; text fails for binary files"),
+			mcp.Enum("auto", "text", "base64"),
+			mcp.DefaultString("auto"),
+		),
+		mcp.WithNumber("start_line",
+			mcp.Description("First line to return, starting at 1; text files only"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("end_line",
+			mcp.Description("Last line to return, inclusive; text files only"),
+			mcp.Min(1),
+		),
+		mcp.WithOutputSchema[ReadFileResult](),
+	)
+
+	return &ReadFileTool{
+		BaseTool: tool.NewBaseTool(
+			"read_file",
+			description,
+			readTool),
+		fsys: fsys,
+	}
+}
+
+// Handle 读取文件
+func (r *ReadFileTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("path")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	startLine, err := optionalInt(request.GetArguments(), "start_line", maxInt)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	endLine, err := optionalInt(request.GetArguments(), "end_line", maxInt)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	encoding := request.GetString("encoding", "auto")
+
+	p, data, size, truncated, err := r.fsys.ReadFile(name, 0)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result := ReadFileResult{Path: p.String(), Size: size, Binary: sandbox.IsBinary(data), Truncated: truncated}
+
+	switch {
+	case encoding == "base64" || (encoding == "auto" && result.Binary):
+		if startLine > 0 || endLine > 0 {
+			return mcp.NewToolResultErrorf("%s: start_line and end_line apply to text only", p), nil
+		}
+		result.Encoding = "base64"
+		result.Content = base64.StdEncoding.EncodeToString(data)
+		return mcp.NewToolResultStructured(result,
+			fmt.Sprintf("%s: %d bytes of binary data (base64): %s", p, len(data), result.Content)), nil
+	case result.Binary:
+		return mcp.NewToolResultErrorf("%s is a binary file, read it with encoding base64", p), nil
+	}
+
+	if truncated {
+		// 不返回被截断的半个字符
+		for len(data) > 0 && !utf8.Valid(data) {
+			data = data[:len(data)-1]
+		}
+	}
+	result.Encoding = "text"
+	result.Content = string(data)
+	if startLine > 0 || endLine > 0 {
+		content, first, last, total, err := lineRange(data, startLine, endLine)
+		if err != nil {
+			return mcp.NewToolResultErrorf("%s: %v", p, err), nil
+		}
+		result.Content, result.StartLine, result.EndLine, result.Lines = content, first, last, total
+	}
+	return mcp.NewToolResultStructured(result, result.Content), nil
+}
+
+// maxInt 行号参数的上限
+const maxInt = 1<<31 - 1
+
+// lineRange 返回第 start 到第 end 行（包含两端，-1 表示不限制），以及实际的起止行号和总行数
+func lineRange(data []byte, start, end int) (content string, first, last, total int, err error) {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	total = len(lines)
+	first, last = max(start, 1), end
+	if last < 0 || last > total {
+		last = total
+	}
+	if first > total {
+		return "", 0, 0, total, fmt.Errorf("start_line %d is beyond the last line %d", first, total)
+	}
+	if last < first {
+		return "", 0, 0, total, fmt.Errorf("end_line %d is before start_line %d", last, first)
+	}
+	return string(bytes.Join(lines[first-1:last], nil)), first, last, total, nil
+}
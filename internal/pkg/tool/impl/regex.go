@@ -0,0 +1,340 @@
+// Package impl regex.go
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RegexTool 正则表达式工具，使用 RE2 语法，匹配时间与输入长度成线性关系
+type RegexTool struct {
+	tool.BaseTool
+}
+
+// 正则表达式工具的输入上限
+const (
+	maxRegexTextBytes    = 1 << 20
+	maxRegexPatternBytes = 4096
+	maxRegexMatches      = 10000
+	defaultRegexMatches  = 100
+	// maxRegexCaptures 一次调用最多保存的捕获组位置数，防止大量匹配乘以大量捕获组耗尽内存
+	maxRegexCaptures = 1 << 20
+)
+
+// RegexGroup 一个捕获组的匹配结果，位置以 Unicode 码点计，未参与匹配的组 start 和 end 为 -1
+type RegexGroup struct {
+	Name  string `json:"name,omitempty"`
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// RegexMatch 一次匹配的结果，位置以 Unicode 码点计，end 不包含在匹配内
+type RegexMatch struct {
+	Text   string       `json:"text"`
+	Start  int          `json:"start"`
+	End    int          `json:"end"`
+	Groups []RegexGroup `json:"groups,omitempty"`
+}
+
+// RegexResult 正则表达式工具的结构化结果
+type RegexResult struct {
+	Operation string       `json:"operation"`
+	Matched   bool         `json:"matched"`
+	Count     int          `json:"count"`               // 匹配、替换或拆分得到的数量
+	Truncated bool         `json:"truncated,omitempty"` // 是否因为 limit 停止
+	Matches   []RegexMatch `json:"matches,omitempty"`
+	Result    *string      `json:"result,omitempty"` // replace 的结果
+	Parts     []string     `json:"parts,omitempty"`  // split 的结果
+}
+
+// NewRegexTool 创建正则表达式工具
+func NewRegexTool() tool.Handler {
+	description := "Search text with a regular expression (Go RE2 syntax, no backreferences or lookaround in patterns): " +
+		"match returns the first match, find_all every match with capture groups, replace substitutes matches and split cuts the text at them. " +
+		"Positions are counted in Unicode code points."
+	regexTool := mcp.NewTool("regex",
+		mcp.WithDescription(description),
+		mcp.WithString("operation",
+			mcp.Required(),
+			mcp.Description("What to do with the matches"),
+			mcp.Enum("match", "find_all", "replace", "split"),
+		),
+		mcp.WithString("pattern",
+			mcp.Required(),
+			mcp.Description("RE2 pattern, e.g. (?P<year>\\d{4})-(\\d{2}); named groups use (?P<name>...) or (?<name>...)"),
+			mcp.MaxLength(maxRegexPatternBytes),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The text to search, at most 1 MiB"),
+		),
+		mcp.WithString("replacement",
+			mcp.Description("Replacement for replace; $1 or ${name} insert capture groups, $$ is a literal $. "+
+				"Use ${1}x rather than $1x when a group is followed by a letter, digit or underscore"),
+		),
+		mcp.WithBoolean("literal",
+			mcp.Description("Insert replacement as is, without expanding $ references"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("ignore_case",
+			mcp.Description("Case-insensitive matching, same as the (?i) flag"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("multiline",
+			mcp.Description("^ and $ match at line breaks, same as the (?m) flag"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("dot_all",
+			mcp.Description(". also matches a line break, same as the (?s) flag"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of matches for find_all and replace, or of parts for split; defaults to %d for find_all, all matches for replace and %d for split", defaultRegexMatches, maxRegexMatches)),
+			mcp.Min(1),
+			mcp.Max(maxRegexMatches),
+		),
+		mcp.WithOutputSchema[RegexResult](),
+	)
+
+	return &RegexTool{
+		BaseTool: tool.NewBaseTool(
+			"regex",
+			description,
+			regexTool),
+	}
+}
+
+// Handle 编译正则表达式并执行操作
+func (r *RegexTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	operation, err := request.RequireString("operation")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pattern, err := request.RequireString("pattern")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(pattern) > maxRegexPatternBytes {
+		return mcp.NewToolResultErrorf("pattern is %d bytes, the limit is %d", len(pattern), maxRegexPatternBytes), nil
+	}
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(text) > maxRegexTextBytes {
+		return mcp.NewToolResultErrorf("text is %d bytes, the limit is %d", len(text), maxRegexTextBytes), nil
+	}
+	limit, err := optionalInt(request.GetArguments(), "limit", maxRegexMatches)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if limit == 0 {
+		return mcp.NewToolResultError("limit must be at least 1"), nil
+	}
+
+	re, err := regexp.Compile(regexFlags(request) + pattern)
+	if err != nil {
+		return patternError(pattern, err), nil
+	}
+
+	// replace 未指定 limit 时替换全部匹配，由 regexReplace 按输出大小限制
+	if limit < 0 && operation != "replace" {
+		limit = maxRegexMatches
+		if operation == "find_all" {
+			limit = defaultRegexMatches
+		}
+	}
+
+	switch operation {
+	case "match":
+		return regexFind(re, text, operation, 1)
+	case "find_all":
+		return regexFind(re, text, operation, limit)
+	case "replace":
+		replacement, ok := request.GetArguments()["replacement"].(string)
+		if !ok {
+			return mcp.NewToolResultError("replace requires a replacement string"), nil
+		}
+		return regexReplace(re, text, replacement, request.GetBool("literal", false), limit)
+	case "split":
+		return regexSplit(re, text, limit)
+	default:
+		return mcp.NewToolResultErrorf("unknown operation %q, must be one of match, find_all, replace, split", operation), nil
+	}
+}
+
+// regexFlags 将布尔参数转换为 RE2 的标志前缀
+func regexFlags(request mcp.CallToolRequest) string {
+	var flags string
+	if request.GetBool("ignore_case", false) {
+		flags += "i"
+	}
+	if request.GetBool("multiline", false) {
+		flags += "m"
+	}
+	if request.GetBool("dot_all", false) {
+		flags += "s"
+	}
+	if flags == "" {
+		return ""
+	}
+	return "(?" + flags + ")"
+}
+
+// patternError 返回标出出错位置的编译错误
+func patternError(pattern string, err error) *mcp.CallToolResult {
+	var syntaxErr *syntax.Error
+	if !errors.As(err, &syntaxErr) {
+		return mcp.NewToolResultErrorf("invalid pattern: %v", err)
+	}
+	// syntax.Error 只给出出错的片段，在原始表达式中定位它
+	offset := strings.Index(pattern, syntaxErr.Expr)
+	if syntaxErr.Expr == "" || offset < 0 {
+		return mcp.NewToolResultErrorf("invalid pattern: %s", syntaxErr.Code)
+	}
+	pos := utf8.RuneCountInString(pattern[:offset]) + 1
+	marker := strings.Repeat(" ", pos-1) + strings.Repeat("^", max(1, utf8.RuneCountInString(syntaxErr.Expr)))
+	return mcp.NewToolResultErrorf("invalid pattern at position %d: %s: `%s`\n%s\n%s",
+		pos, syntaxErr.Code, syntaxErr.Expr, pattern, marker)
+}
+
+// captureLimit 按捕获组的数量收紧 limit，使保存的位置数不超过 maxRegexCaptures
+func captureLimit(re *regexp.Regexp, limit int) int {
+	return max(1, min(limit, maxRegexCaptures/(re.NumSubexp()+1)))
+}
+
+// regexFind 查找最多 limit 个匹配，捕获组很多时会更早停止
+func regexFind(re *regexp.Regexp, text, operation string, limit int) (*mcp.CallToolResult, error) {
+	limit = captureLimit(re, limit)
+	// 多找一个，用于判断是否被截断
+	indexes := re.FindAllStringSubmatchIndex(text, limit+1)
+	result := RegexResult{Operation: operation}
+	if len(indexes) > limit {
+		// match 只关心第一个匹配，不算截断
+		indexes, result.Truncated = indexes[:limit], operation == "find_all"
+	}
+	result.Matched = len(indexes) > 0
+	result.Count = len(indexes)
+	result.Matches = make([]RegexMatch, 0, len(indexes))
+
+	names := re.SubexpNames()
+	var pos runePositions
+	for _, loc := range indexes {
+		start := pos.at(text, loc[0])
+		match := RegexMatch{
+			Text:  text[loc[0]:loc[1]],
+			Start: start,
+			End:   start + utf8.RuneCountInString(text[loc[0]:loc[1]]),
+		}
+		for i := 1; i < len(names); i++ {
+			group := RegexGroup{Name: names[i], Start: -1, End: -1}
+			if s, e := loc[2*i], loc[2*i+1]; s >= 0 {
+				group.Text = text[s:e]
+				group.Start = start + utf8.RuneCountInString(text[loc[0]:s])
+				group.End = group.Start + utf8.RuneCountInString(group.Text)
+			}
+			match.Groups = append(match.Groups, group)
+		}
+		result.Matches = append(result.Matches, match)
+	}
+
+	summary := "no match"
+	switch {
+	case operation == "match" && result.Matched:
+		summary = fmt.Sprintf("matched %q at %d-%d", result.Matches[0].Text, result.Matches[0].Start, result.Matches[0].End)
+	case result.Matched:
+		summary = fmt.Sprintf("%d matches", result.Count)
+		if result.Truncated {
+			summary += " (limit reached)"
+		}
+	}
+	return mcp.NewToolResultStructured(result, summary), nil
+}
+
+// regexReplace 替换最多 limit 个匹配，limit 小于 0 时替换全部匹配；
+// 替换文本引用捕获组且捕获组很多时，指定的 limit 会更早停止，替换全部匹配则返回错误
+func regexReplace(re *regexp.Regexp, text, replacement string, literal bool, limit int) (*mcp.CallToolResult, error) {
+	all := limit < 0
+	if all {
+		// 空匹配最多出现 len(text)+1 次
+		limit = len(text) + 1
+	}
+	// 只有需要展开 $ 引用时才保存捕获组的位置
+	expand := !literal && strings.Contains(replacement, "$")
+	var indexes [][]int
+	if expand {
+		limit = captureLimit(re, limit)
+		indexes = re.FindAllStringSubmatchIndex(text, limit+1)
+	} else {
+		indexes = re.FindAllStringIndex(text, limit+1)
+	}
+	truncated := len(indexes) > limit
+	if truncated && all {
+		return mcp.NewToolResultErrorf("the replacement expands capture groups in more than %d matches; set limit or literal", limit), nil
+	}
+	if truncated {
+		indexes = indexes[:limit]
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range indexes {
+		b.WriteString(text[last:loc[0]])
+		if expand {
+			b.Write(re.ExpandString(nil, replacement, text, loc))
+		} else {
+			b.WriteString(replacement)
+		}
+		last = loc[1]
+		if b.Len() > maxRegexTextBytes*2 {
+			return mcp.NewToolResultErrorf("the result exceeds %d bytes", maxRegexTextBytes*2), nil
+		}
+	}
+	b.WriteString(text[last:])
+
+	replaced := b.String()
+	return mcp.NewToolResultStructured(RegexResult{
+		Operation: "replace",
+		Matched:   len(indexes) > 0,
+		Count:     len(indexes),
+		Truncated: truncated,
+		Result:    &replaced,
+	}, replaced), nil
+}
+
+// regexSplit 在匹配处拆分文本，最多拆成 limit 段，最后一段是未拆分的剩余文本
+func regexSplit(re *regexp.Regexp, text string, limit int) (*mcp.CallToolResult, error) {
+	// 多拆一段，用于判断是否被截断
+	parts := re.Split(text, limit+1)
+	truncated := len(parts) > limit
+	if truncated {
+		parts = re.Split(text, limit)
+	}
+	return mcp.NewToolResultStructured(RegexResult{
+		Operation: "split",
+		Matched:   len(parts) > 1,
+		Count:     len(parts),
+		Truncated: truncated,
+		Parts:     parts,
+	}, strings.Join(parts, "\n")), nil
+}
+
+// runePositions 将递增的字节偏移转换为码点偏移，避免每次从头计数
+type runePositions struct {
+	byteOffset int
+	runeOffset int
+}
+
+func (p *runePositions) at(text string, offset int) int {
+	p.runeOffset += utf8.RuneCountInString(text[p.byteOffset:offset])
+	p.byteOffset = offset
+	return p.runeOffset
+}
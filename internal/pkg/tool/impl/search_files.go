@@ -0,0 +1,202 @@
+// Package impl search_files.go
+package impl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SearchFilesTool 搜索文件工具
+type SearchFilesTool struct {
+	tool.BaseTool
+	fsys *sandbox.FS
+}
+
+// SearchMatch 一个搜索结果，按内容搜索时 Line 和 Text 为匹配的行
+type SearchMatch struct {
+	Path string `json:"path"`
+	Line int    `json:"line,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// SearchFilesResult 搜索文件的结构化结果
+type SearchFilesResult struct {
+	Path      string        `json:"path"`
+	Matches   []SearchMatch `json:"matches"`
+	Scanned   int           `json:"scanned"`             // 检查过的文件数
+	Skipped   int           `json:"skipped,omitempty"`   // 因为是二进制文件或超过大小上限而跳过的文件数
+	Truncated bool          `json:"truncated,omitempty"` // 结果数达到上限
+}
+
+// 搜索的限制
+const (
+	maxSearchResults     = 1000
+	defaultSearchResults = 100
+	maxSearchFiles       = 100000
+	maxSearchLineLength  = 500
+)
+
+// NewSearchFilesTool 创建搜索文件工具
+func NewSearchFilesTool(fsys *sandbox.FS) tool.Handler {
+	description := fmt.Sprintf("Search the allowed directories (%s) recursively for files whose names match a glob pattern "+
+		"and/or whose text content matches a regular expression; binary files and files over %d bytes are skipped",
+		strings.Join(fsys.Roots(), ", "), fsys.MaxFileBytes())
+	searchTool := mcp.NewTool("search_files",
+		mcp.WithDescription(description),
+		pathParam("path", "The directory to search, defaults to the first allowed directory", fsys, false),
+		mcp.WithString("name",
+			mcp.Description("Glob pattern for file names, e.g. *.go or config.*; patterns containing / match the path relative to the searched directory"),
+		),
+		mcp.WithString("content",
+			mcp.Description("RE2 regular expression to search for in each line of text files"),
+		),
+		mcp.WithBoolean("ignore_case",
+			mcp.Description("Case-insensitive content matching"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithBoolean("include_hidden",
+			mcp.Description("Also search files and directories whose names start with a dot"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of matches to return"),
+			mcp.Min(1),
+			mcp.Max(maxSearchResults),
+			mcp.DefaultNumber(defaultSearchResults),
+		),
+		mcp.WithOutputSchema[SearchFilesResult](),
+	)
+
+	return &SearchFilesTool{
+		BaseTool: tool.NewBaseTool(
+			"search_files",
+			description,
+			searchTool),
+		fsys: fsys,
+	}
+}
+
+// Handle 搜索文件
+func (s *SearchFilesTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namePattern := request.GetString("name", "")
+	contentPattern := request.GetString("content", "")
+	if namePattern == "" && contentPattern == "" {
+		return mcp.NewToolResultError("give a name pattern, a content pattern or both"), nil
+	}
+	if _, err := filepath.Match(namePattern, ""); err != nil {
+		return mcp.NewToolResultErrorf("invalid name pattern %q: %v", namePattern, err), nil
+	}
+	var content *regexp.Regexp
+	if contentPattern != "" {
+		if request.GetBool("ignore_case", false) {
+			contentPattern = "(?i)" + contentPattern
+		}
+		var err error
+		if content, err = regexp.Compile(contentPattern); err != nil {
+			return mcp.NewToolResultErrorf("invalid content pattern: %v", err), nil
+		}
+	}
+	limit, err := optionalInt(request.GetArguments(), "max_results", maxSearchResults)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if limit <= 0 {
+		limit = defaultSearchResults
+	}
+	includeHidden := request.GetBool("include_hidden", false)
+
+	result := SearchFilesResult{Matches: []SearchMatch{}}
+	start, err := s.fsys.Resolve(request.GetString("path", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	_, err = s.fsys.Walk(start.String(), maxListDepth*10, func(p sandbox.Path, entry fs.DirEntry, _ int) error {
+		if !includeHidden && isHidden(entry.Name()) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if namePattern != "" && !matchName(namePattern, start, p) {
+			return nil
+		}
+		if result.Scanned == maxSearchFiles {
+			result.Truncated = true
+			return fs.SkipAll
+		}
+		result.Scanned++
+		if content == nil {
+			result.Matches = append(result.Matches, SearchMatch{Path: p.String()})
+		} else {
+			s.searchContent(p, content, &result, limit)
+		}
+		if len(result.Matches) >= limit {
+			result.Truncated = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result.Path = start.String()
+	lines := make([]string, len(result.Matches))
+	for i, m := range result.Matches {
+		lines[i] = m.Path
+		if m.Line > 0 {
+			lines[i] = fmt.Sprintf("%s:%d: %s", m.Path, m.Line, m.Text)
+		}
+	}
+	text := strings.Join(lines, "\n")
+	if len(lines) == 0 {
+		text = fmt.Sprintf("no matches in %d files", result.Scanned)
+	}
+	return mcp.NewToolResultStructured(result, text), nil
+}
+
+// matchName 按名称模式匹配文件，包含 / 的模式匹配相对于搜索目录的路径
+func matchName(pattern string, start, p sandbox.Path) bool {
+	name := filepath.Base(p.Rel)
+	if strings.Contains(pattern, "/") {
+		name, _ = filepath.Rel(start.Rel, p.Rel)
+		name = filepath.ToSlash(name)
+	}
+	matched, _ := filepath.Match(pattern, name)
+	return matched
+}
+
+// searchContent 在文本文件中逐行搜索，二进制文件和过大的文件计入 Skipped
+func (s *SearchFilesTool) searchContent(p sandbox.Path, content *regexp.Regexp, result *SearchFilesResult, limit int) {
+	_, data, _, truncated, err := s.fsys.ReadFile(p.String(), 0)
+	if err != nil || truncated || sandbox.IsBinary(data) {
+		result.Skipped++
+		return
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan() && len(result.Matches) < limit; line++ {
+		text := scanner.Text()
+		if !content.MatchString(text) {
+			continue
+		}
+		text = strings.TrimRight(text, "\r")
+		if len(text) > maxSearchLineLength {
+			text = strings.ToValidUTF8(text[:maxSearchLineLength], "") + "..."
+		}
+		result.Matches = append(result.Matches, SearchMatch{Path: p.String(), Line: line, Text: text})
+	}
+}
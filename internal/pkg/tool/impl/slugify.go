@@ -0,0 +1,64 @@
+// Package impl slugify.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/textutil"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SlugifyTool slug 生成工具
+type SlugifyTool struct {
+	tool.BaseTool
+}
+
+// maxSlugLength slug 的最大长度
+const maxSlugLength = 1000
+
+// NewSlugifyTool 创建 slug 生成工具
+func NewSlugifyTool() tool.Handler {
+	description := "Turn text into a lowercase URL slug: accents are removed (Crème Brûlée → creme-brulee) and other characters become separators"
+	slugifyTool := mcp.NewTool("slugify",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The text to convert"),
+		),
+		mcp.WithString("separator",
+			mcp.Description("Separator between words"),
+			mcp.DefaultString("-"),
+		),
+		mcp.WithNumber("max_length",
+			mcp.Description("Maximum slug length in characters, cut at a word boundary when possible; 0 means no limit"),
+			mcp.Min(0),
+			mcp.Max(maxSlugLength),
+		),
+	)
+
+	return &SlugifyTool{
+		BaseTool: tool.NewBaseTool(
+			"slugify",
+			description,
+			slugifyTool),
+	}
+}
+
+// Handle 生成 slug
+func (s *SlugifyTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	maxLength, err := optionalInt(request.GetArguments(), "max_length", maxSlugLength)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slug := textutil.Slugify(text, request.GetString("separator", "-"), maxLength)
+	if slug == "" {
+		return mcp.NewToolResultError("the text contains no letters or digits"), nil
+	}
+	return mcp.NewToolResultText(slug), nil
+}
@@ -0,0 +1,66 @@
+// Package impl stat.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StatTool 文件信息工具
+type StatTool struct {
+	tool.BaseTool
+	fsys *sandbox.FS
+}
+
+// StatResult 文件信息的结构化结果
+type StatResult struct {
+	FileInfo
+	Target string `json:"target,omitempty"` // 符号链接的目标
+}
+
+// NewStatTool 创建文件信息工具
+func NewStatTool(fsys *sandbox.FS) tool.Handler {
+	description := fmt.Sprintf("Show the type, size, permissions and modification time of a file or directory in the allowed directories (%s); "+
+		"symbolic links are reported, not followed", strings.Join(fsys.Roots(), ", "))
+	statTool := mcp.NewTool("stat",
+		mcp.WithDescription(description),
+		pathParam("path", "The file or directory", fsys, true),
+		mcp.WithOutputSchema[StatResult](),
+	)
+
+	return &StatTool{
+		BaseTool: tool.NewBaseTool(
+			"stat",
+			description,
+			statTool),
+		fsys: fsys,
+	}
+}
+
+// Handle 返回文件信息
+func (s *StatTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("path")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	p, info, err := s.fsys.Stat(name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result := StatResult{FileInfo: newFileInfo(p, info)}
+	if result.Type == "symlink" {
+		result.Target, _ = s.fsys.Readlink(p)
+	}
+
+	text := fmt.Sprintf("%s: %s, %d bytes, %s, modified %s", result.Path, result.Type, result.Size, result.Mode, result.Modified)
+	if result.Target != "" {
+		text += ", -> " + result.Target
+	}
+	return mcp.NewToolResultStructured(result, text), nil
+}
@@ -0,0 +1,324 @@
+// Package impl statistics.go
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 统计工具的输入限制
+const (
+	maxStatisticsValues   = 1_000_000 // 单次调用的最大数值个数
+	maxHistogramBins      = 1000      // 直方图的最大分组数
+	maxStatisticsProblems = 10        // 错误信息中最多列出的不合法元素个数
+)
+
+// 默认计算的百分位数
+var defaultPercentiles = []float64{25, 50, 75, 90, 95, 99}
+
+// StatisticsTool 描述性统计工具
+type StatisticsTool struct {
+	tool.BaseTool
+}
+
+// StatisticsResult 描述性统计的结构化结果
+type StatisticsResult struct {
+	Count       int            `json:"count"`
+	Sum         float64        `json:"sum"`
+	Mean        float64        `json:"mean"`
+	Median      float64        `json:"median"`
+	Mode        []float64      `json:"mode"`       // 出现次数最多的值，所有值都只出现一次时为空
+	ModeCount   int            `json:"mode_count"` // mode 中每个值的出现次数
+	Variance    float64        `json:"variance"`
+	StdDev      float64        `json:"stddev"`
+	Sample      bool           `json:"sample"` // variance 是否为样本方差（除以 n-1）
+	Min         float64        `json:"min"`
+	Max         float64        `json:"max"`
+	Range       float64        `json:"range"`
+	Percentiles []Percentile   `json:"percentiles"`
+	Histogram   []HistogramBin `json:"histogram"`
+}
+
+// Percentile 单个百分位数，按线性插值计算
+type Percentile struct {
+	Percentile float64 `json:"percentile"`
+	Value      float64 `json:"value"`
+}
+
+// HistogramBin 直方图的一个分组，区间为 [lower, upper)，最后一组包含 upper
+type HistogramBin struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// NewStatisticsTool 创建描述性统计工具
+func NewStatisticsTool() tool.Handler {
+	description := "Compute descriptive statistics for a list of numbers: count, sum, mean, median, mode, " +
+		"variance, standard deviation, min/max, percentiles and a histogram"
+	statisticsTool := mcp.NewTool("statistics",
+		mcp.WithDescription(description),
+		mcp.WithArray("numbers",
+			mcp.Required(),
+			mcp.Description("The numbers to analyze, at most "+strconv.Itoa(maxStatisticsValues)+" values"),
+			mcp.Items(map[string]any{"type": "number"}),
+			mcp.MinItems(1),
+			mcp.MaxItems(maxStatisticsValues),
+		),
+		mcp.WithArray("percentiles",
+			mcp.Description("Percentiles to compute, each between 0 and 100; defaults to "+formatFloats(defaultPercentiles)),
+			mcp.Items(map[string]any{"type": "number", "minimum": 0, "maximum": 100}),
+		),
+		mcp.WithNumber("bins",
+			mcp.Description("Number of histogram bins; defaults to Sturges' rule, ceil(log2(n))+1"),
+			mcp.Min(1),
+			mcp.Max(maxHistogramBins),
+		),
+		mcp.WithBoolean("sample",
+			mcp.Description("Use the sample variance (divide by n-1) instead of the population variance (divide by n)"),
+			mcp.DefaultBool(true),
+		),
+		mcp.WithOutputSchema[StatisticsResult](),
+	)
+
+	return &StatisticsTool{
+		BaseTool: tool.NewBaseTool(
+			"statistics",
+			description,
+			statisticsTool),
+	}
+}
+
+// Handle 校验输入并计算统计量
+func (s *StatisticsTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := request.GetArguments()
+	values, err := numberList("numbers", args["numbers"])
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(values) == 0 {
+		return mcp.NewToolResultError("numbers must contain at least one number"), nil
+	}
+	if len(values) > maxStatisticsValues {
+		return mcp.NewToolResultErrorf("numbers has %d values, at most %d are allowed", len(values), maxStatisticsValues), nil
+	}
+
+	percentiles := defaultPercentiles
+	if args["percentiles"] != nil {
+		if percentiles, err = numberList("percentiles", args["percentiles"]); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		for i, p := range percentiles {
+			if p < 0 || p > 100 {
+				return mcp.NewToolResultErrorf("percentiles[%d] must be between 0 and 100, got %v", i, p), nil
+			}
+		}
+	}
+
+	bins, err := optionalInt(args, "bins", maxHistogramBins)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if bins == 0 {
+		return mcp.NewToolResultError("bins must be at least 1"), nil
+	}
+	if bins < 0 {
+		bins = min(int(math.Ceil(math.Log2(float64(len(values)))))+1, maxHistogramBins)
+	}
+
+	result, err := describe(values, percentiles, bins, request.GetBool("sample", true))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	text := fmt.Sprintf("count=%d mean=%s median=%s stddev=%s min=%s max=%s",
+		result.Count, formatFloat(result.Mean), formatFloat(result.Median), formatFloat(result.StdDev),
+		formatFloat(result.Min), formatFloat(result.Max))
+	return mcp.NewToolResultStructured(result, text), nil
+}
+
+// numberList 将 JSON 数组转换为数值列表，错误信息指出不合法的元素下标
+func numberList(name string, value any) ([]float64, error) {
+	if value == nil {
+		return nil, fmt.Errorf("required argument %q not found", name)
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of numbers, got %T", name, value)
+	}
+
+	values := make([]float64, len(items))
+	var problems []string
+	invalid := 0
+	for i, item := range items {
+		number, ok := item.(float64)
+		if ok && !math.IsNaN(number) && !math.IsInf(number, 0) {
+			values[i] = number
+			continue
+		}
+		invalid++
+		if len(problems) < maxStatisticsProblems {
+			problems = append(problems, fmt.Sprintf("%s[%d]: expected a number, got %s", name, i, describeValue(item)))
+		}
+	}
+	if invalid == 0 {
+		return values, nil
+	}
+	if invalid > len(problems) {
+		problems = append(problems, fmt.Sprintf("... and %d more", invalid-len(problems)))
+	}
+	return nil, fmt.Errorf("%s contains %d non-numeric entries:\n%s", name, invalid, strings.Join(problems, "\n"))
+}
+
+// describeValue 描述不合法元素的类型和值
+func describeValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return fmt.Sprintf("boolean %t", v)
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T %v", value, value)
+	}
+}
+
+// describe 计算统计量，values 会被排序
+// 排序一次后即可得到中位数、百分位数、众数和极值，均值和方差使用 Welford 算法在一次遍历中计算
+func describe(values []float64, percentiles []float64, bins int, sample bool) (*StatisticsResult, error) {
+	n := len(values)
+	var mean, m2, sum, compensation float64
+	for i, v := range values {
+		// Welford 算法，避免大数相减造成的精度损失
+		delta := v - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (v - mean)
+		// Neumaier 补偿求和
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			compensation += (sum - t) + v
+		} else {
+			compensation += (v - t) + sum
+		}
+		sum = t
+	}
+	sum += compensation
+	if math.IsNaN(sum) || math.IsInf(sum, 0) || math.IsNaN(m2) || math.IsInf(m2, 0) {
+		return nil, errors.New("the values are too large: sum or variance overflows float64")
+	}
+
+	// 只有一个值时样本方差没有定义，返回 0
+	var variance float64
+	switch {
+	case sample && n > 1:
+		variance = m2 / float64(n-1)
+	case !sample:
+		variance = m2 / float64(n)
+	}
+
+	slices.Sort(values)
+	mode, modeCount := modes(values)
+	result := &StatisticsResult{
+		Count:       n,
+		Sum:         sum,
+		Mean:        mean,
+		Median:      percentile(values, 50),
+		Mode:        mode,
+		ModeCount:   modeCount,
+		Variance:    variance,
+		StdDev:      math.Sqrt(variance),
+		Sample:      sample,
+		Min:         values[0],
+		Max:         values[n-1],
+		Range:       values[n-1] - values[0],
+		Percentiles: make([]Percentile, 0, len(percentiles)),
+		Histogram:   histogram(values, bins),
+	}
+	for _, p := range percentiles {
+		result.Percentiles = append(result.Percentiles, Percentile{Percentile: p, Value: percentile(values, p)})
+	}
+	return result, nil
+}
+
+// percentile 对已排序的值按线性插值计算百分位数，与 numpy 的默认方法和 Excel 的 PERCENTILE.INC 一致
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := rank - float64(lower)
+	return sorted[lower] + frac*(sorted[lower+1]-sorted[lower])
+}
+
+// modes 返回已排序的值中出现次数最多的值及其次数，所有值都只出现一次时返回空列表
+func modes(sorted []float64) ([]float64, int) {
+	best := 0
+	mode := []float64{}
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		switch count := j - i; {
+		case count > best:
+			best = count
+			mode = append(mode[:0], sorted[i])
+		case count == best:
+			mode = append(mode, sorted[i])
+		}
+		i = j
+	}
+	if best == 1 && len(sorted) > 1 {
+		return []float64{}, 1
+	}
+	return mode, best
+}
+
+// histogram 将已排序的值按等宽区间分组
+func histogram(sorted []float64, bins int) []HistogramBin {
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo == hi {
+		return []HistogramBin{{Lower: lo, Upper: hi, Count: len(sorted)}}
+	}
+
+	width := (hi - lo) / float64(bins)
+	result := make([]HistogramBin, bins)
+	for i := range result {
+		result[i].Lower = lo + float64(i)*width
+		result[i].Upper = lo + float64(i+1)*width
+	}
+	result[bins-1].Upper = hi
+	for _, v := range sorted {
+		// 最大值落在最后一组
+		i := min(int((v-lo)/width), bins-1)
+		result[i].Count++
+	}
+	return result
+}
+
+// formatFloat 使用最短的十进制表示格式化浮点数
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// formatFloats 格式化浮点数列表
+func formatFloats(values []float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = formatFloat(v)
+	}
+	return strings.Join(parts, ", ")
+}
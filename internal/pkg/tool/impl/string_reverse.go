@@ -2,11 +2,13 @@ package impl
 
 import (
 	"context"
+	"mcp-go-tutorials/internal/pkg/textutil"
 	"mcp-go-tutorials/internal/pkg/tool"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// StringReverseTool 字符串反转工具，按字形簇反转
 type StringReverseTool struct {
 	tool.BaseTool
 }
@@ -14,7 +16,7 @@ type StringReverseTool struct {
 // NewStringReverseTool 创建字符串反转工具
 func NewStringReverseTool() tool.Handler {
 	reverseTool := mcp.NewTool("reverse_string",
-		mcp.WithDescription("Reverse a string"),
+		mcp.WithDescription("Reverse a string by user-perceived characters, keeping combining marks and emoji sequences intact"),
 		mcp.WithString("text",
 			mcp.Required(),
 			mcp.Description("The text to reverse"),
@@ -23,7 +25,7 @@ func NewStringReverseTool() tool.Handler {
 	return &StringReverseTool{
 		BaseTool: tool.NewBaseTool(
 			"reverse_string",
-			"Reverse a string",
+			"Reverse a string by user-perceived characters, keeping combining marks and emoji sequences intact",
 			reverseTool),
 	}
 }
@@ -34,10 +36,5 @@ func (s StringReverseTool) Handle(_ context.Context, request mcp.CallToolRequest
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	runes := []rune(text)
-	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
-		runes[i], runes[j] = runes[j], runes[i]
-	}
-
-	return mcp.NewToolResultText(string(runes)), nil
+	return mcp.NewToolResultText(textutil.Reverse(text)), nil
 }
@@ -0,0 +1,49 @@
+// Package impl text_stats.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/textutil"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TextStatsTool 文本统计工具
+type TextStatsTool struct {
+	tool.BaseTool
+}
+
+// NewTextStatsTool 创建文本统计工具
+func NewTextStatsTool() tool.Handler {
+	description := "Count the bytes, Unicode code points, user-perceived characters (grapheme clusters), words and lines of a text"
+	textStatsTool := mcp.NewTool("text_stats",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The text to count; each Chinese or Japanese character counts as a word"),
+		),
+		mcp.WithOutputSchema[textutil.Counts](),
+	)
+
+	return &TextStatsTool{
+		BaseTool: tool.NewBaseTool(
+			"text_stats",
+			description,
+			textStatsTool),
+	}
+}
+
+// Handle 统计文本
+func (t *TextStatsTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	counts := textutil.Count(text)
+	summary := fmt.Sprintf("bytes=%d runes=%d graphemes=%d words=%d lines=%d",
+		counts.Bytes, counts.Runes, counts.Graphemes, counts.Words, counts.Lines)
+	return mcp.NewToolResultStructured(counts, summary), nil
+}
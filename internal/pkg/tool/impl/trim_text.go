@@ -0,0 +1,83 @@
+// Package impl trim_text.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TrimTextTool 文本裁剪工具
+type TrimTextTool struct {
+	tool.BaseTool
+}
+
+// NewTrimTextTool 创建文本裁剪工具
+func NewTrimTextTool() tool.Handler {
+	description := "Trim whitespace or given characters from the ends of a text and optionally collapse runs of whitespace"
+	trimTool := mcp.NewTool("trim_text",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The text to trim"),
+		),
+		mcp.WithString("side",
+			mcp.Description("Which ends to trim"),
+			mcp.Enum("both", "left", "right"),
+			mcp.DefaultString("both"),
+		),
+		mcp.WithString("characters",
+			mcp.Description("Characters to trim instead of Unicode whitespace"),
+		),
+		mcp.WithBoolean("collapse_whitespace",
+			mcp.Description("Replace each run of whitespace inside the text with a single space; line breaks are kept"),
+			mcp.DefaultBool(false),
+		),
+	)
+
+	return &TrimTextTool{
+		BaseTool: tool.NewBaseTool(
+			"trim_text",
+			description,
+			trimTool),
+	}
+}
+
+// Handle 裁剪文本
+func (t *TrimTextTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	trim := unicode.IsSpace
+	if characters := request.GetString("characters", ""); characters != "" {
+		trim = func(r rune) bool { return strings.ContainsRune(characters, r) }
+	}
+	switch side := request.GetString("side", "both"); side {
+	case "both":
+		text = strings.TrimFunc(text, trim)
+	case "left":
+		text = strings.TrimLeftFunc(text, trim)
+	case "right":
+		text = strings.TrimRightFunc(text, trim)
+	default:
+		return mcp.NewToolResultErrorf("unknown side %q, must be one of both, left, right", side), nil
+	}
+	if request.GetBool("collapse_whitespace", false) {
+		text = collapseWhitespace(text)
+	}
+	return mcp.NewToolResultText(text), nil
+}
+
+// horizontalSpace 匹配除换行外的连续空白
+var horizontalSpace = regexp.MustCompile(`[^\S\n]+`)
+
+// collapseWhitespace 将连续的空白替换为一个空格，保留换行
+func collapseWhitespace(text string) string {
+	return horizontalSpace.ReplaceAllString(text, " ")
+}
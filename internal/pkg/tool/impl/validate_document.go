@@ -0,0 +1,59 @@
+// Package impl validate_document.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/document"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ValidateDocumentTool 文档语法校验工具
+type ValidateDocumentTool struct {
+	tool.BaseTool
+}
+
+// ValidateDocumentResult 文档语法校验的结构化结果
+type ValidateDocumentResult struct {
+	Valid  bool           `json:"valid"`
+	Format string         `json:"format"`
+	Type   string         `json:"type,omitempty"` // 顶层值的类型
+	Error  *DocumentError `json:"error,omitempty"`
+}
+
+// NewValidateDocumentTool 创建文档语法校验工具
+func NewValidateDocumentTool() tool.Handler {
+	description := "Check whether a JSON, YAML or TOML document is well-formed and report the line and column of the first syntax error"
+	validateTool := mcp.NewTool("validate_document",
+		mcp.WithDescription(description),
+		documentParam("document", "The document to check"),
+		formatParam("format", "Format of the document"),
+		mcp.WithOutputSchema[ValidateDocumentResult](),
+	)
+
+	return &ValidateDocumentTool{
+		BaseTool: tool.NewBaseTool(
+			"validate_document",
+			description,
+			validateTool),
+	}
+}
+
+// Handle 校验文档语法，语法错误作为结果而不是工具错误返回
+func (v *ValidateDocumentTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	parsed, format, err := documentArg(request, "document", request.GetString("format", document.Auto))
+	if err != nil {
+		if format == "" {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultStructured(ValidateDocumentResult{
+			Format: format,
+			Error:  newDocumentError(err),
+		}, err.Error()), nil
+	}
+
+	result := ValidateDocumentResult{Valid: true, Format: format, Type: document.TypeName(parsed)}
+	return mcp.NewToolResultStructured(result, fmt.Sprintf("valid %s (%s)", format, result.Type)), nil
+}
@@ -0,0 +1,87 @@
+// Package impl validate_json_schema.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/document"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ValidateJSONSchemaTool JSON Schema 校验工具
+type ValidateJSONSchemaTool struct {
+	tool.BaseTool
+}
+
+// ValidateJSONSchemaResult JSON Schema 校验的结构化结果
+type ValidateJSONSchemaResult struct {
+	Valid     bool                   `json:"valid"`
+	Format    string                 `json:"format"`
+	Errors    []document.SchemaError `json:"errors"`
+	Truncated bool                   `json:"truncated,omitempty"` // 错误数是否达到上限
+}
+
+// NewValidateJSONSchemaTool 创建 JSON Schema 校验工具
+func NewValidateJSONSchemaTool() tool.Handler {
+	description := "Validate a JSON, YAML or TOML document against a JSON Schema (draft 2020-12 or draft-07) and list every violation with its location. " +
+		"Supports type, enum, const, numeric and string limits, pattern, format (" + strings.Join(document.FormatNames(), ", ") + "), " +
+		"array and object keywords, allOf/anyOf/oneOf/not, if/then/else, dependencies and local $ref; " +
+		"unevaluatedProperties, unevaluatedItems and remote $ref are not supported"
+	validateTool := mcp.NewTool("validate_json_schema",
+		mcp.WithDescription(description),
+		documentParam("document", "The document to validate"),
+		formatParam("format", "Format of the document"),
+		documentParam("schema", "The JSON Schema, in JSON or YAML"),
+		mcp.WithOutputSchema[ValidateJSONSchemaResult](),
+	)
+
+	return &ValidateJSONSchemaTool{
+		BaseTool: tool.NewBaseTool(
+			"validate_json_schema",
+			description,
+			validateTool),
+	}
+}
+
+// Handle 用模式校验文档，校验错误作为结果返回，模式或文档无法解析时返回工具错误
+func (v *ValidateJSONSchemaTool) Handle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	schema, _, err := documentArg(request, "schema", document.Auto)
+	if err != nil {
+		return mcp.NewToolResultErrorf("schema: %v", err), nil
+	}
+	parsed, format, err := documentArg(request, "document", request.GetString("format", document.Auto))
+	if err != nil {
+		return mcp.NewToolResultErrorf("document: %v", err), nil
+	}
+
+	errs, err := document.Validate(ctx, schema, parsed)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result := ValidateJSONSchemaResult{
+		Valid:     len(errs) == 0,
+		Format:    format,
+		Errors:    errs,
+		Truncated: len(errs) == document.MaxSchemaErrors,
+	}
+	if result.Errors == nil {
+		result.Errors = []document.SchemaError{}
+	}
+
+	if result.Valid {
+		return mcp.NewToolResultStructured(result, "valid"), nil
+	}
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = e.String()
+	}
+	noun := "errors"
+	if len(errs) == 1 {
+		noun = "error"
+	}
+	text := fmt.Sprintf("%d %s:\n%s", len(errs), noun, strings.Join(lines, "\n"))
+	return mcp.NewToolResultStructured(result, text), nil
+}
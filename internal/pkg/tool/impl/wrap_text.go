@@ -0,0 +1,62 @@
+// Package impl wrap_text.go
+package impl
+
+import (
+	"context"
+	"mcp-go-tutorials/internal/pkg/textutil"
+	"mcp-go-tutorials/internal/pkg/tool"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WrapTextTool 文本折行工具
+type WrapTextTool struct {
+	tool.BaseTool
+}
+
+// maxWrapWidth 折行宽度的上限
+const maxWrapWidth = 10000
+
+// NewWrapTextTool 创建文本折行工具
+func NewWrapTextTool() tool.Handler {
+	description := "Wrap text to a maximum display width, breaking at spaces; wide characters such as Chinese count as two columns"
+	wrapTool := mcp.NewTool("wrap_text",
+		mcp.WithDescription(description),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("The text to wrap; existing line breaks are kept"),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Maximum line width in columns; longer words are split"),
+			mcp.Min(1),
+			mcp.Max(maxWrapWidth),
+			mcp.DefaultNumber(80),
+		),
+	)
+
+	return &WrapTextTool{
+		BaseTool: tool.NewBaseTool(
+			"wrap_text",
+			description,
+			wrapTool),
+	}
+}
+
+// Handle 折行
+func (w *WrapTextTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	width, err := optionalInt(request.GetArguments(), "width", maxWrapWidth)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	switch {
+	case width < 0:
+		width = 80
+	case width == 0:
+		return mcp.NewToolResultError("width must be at least 1"), nil
+	}
+	return mcp.NewToolResultText(textutil.Wrap(text, width)), nil
+}
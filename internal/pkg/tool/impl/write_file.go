@@ -0,0 +1,101 @@
+// Package impl write_file.go
+package impl
+
+import (
+	"context"
+	"fmt"
+	"mcp-go-tutorials/internal/pkg/codec"
+	"mcp-go-tutorials/internal/pkg/sandbox"
+	"mcp-go-tutorials/internal/pkg/tool"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WriteFileTool 写入文件工具
+type WriteFileTool struct {
+	tool.BaseTool
+	fsys *sandbox.FS
+}
+
+// WriteFileResult 写入文件的结构化结果
+type WriteFileResult struct {
+	Path    string `json:"path"`
+	Bytes   int    `json:"bytes"`   // 写入的字节数
+	Created bool   `json:"created"` // 文件是否是新建的
+	Mode    string `json:"mode"`
+}
+
+// NewWriteFileTool 创建写入文件工具
+func NewWriteFileTool(fsys *sandbox.FS) tool.Handler {
+	description := fmt.Sprintf("Create, overwrite or append to a file in the allowed directories (%s), at most %d bytes",
+		strings.Join(fsys.Roots(), ", "), fsys.MaxFileBytes())
+	writeTool := mcp.NewTool("write_file",
+		mcp.WithDescription(description),
+		pathParam("path", "The file to write", fsys, true),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("The content to write"),
+		),
+		mcp.WithString("encoding",
+			mcp.Description("utf8 writes content as text, base64 decodes it first to write binary data"),
+			mcp.Enum("utf8", codec.Base64),
+			mcp.DefaultString("utf8"),
+		),
+		mcp.WithString("mode",
+			mcp.Description("overwrite replaces an existing file, append adds to its end, create fails if the file exists"),
+			mcp.Enum(sandbox.WriteOverwrite, sandbox.WriteAppend, sandbox.WriteCreate),
+			mcp.DefaultString(sandbox.WriteOverwrite),
+		),
+		mcp.WithBoolean("create_dirs",
+			mcp.Description("Create missing parent directories"),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithOutputSchema[WriteFileResult](),
+	)
+
+	return &WriteFileTool{
+		BaseTool: tool.NewBaseTool(
+			"write_file",
+			description,
+			writeTool),
+		fsys: fsys,
+	}
+}
+
+// Handle 写入文件
+func (w *WriteFileTool) Handle(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("path")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	content, err := request.RequireString("content")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	data := []byte(content)
+	if request.GetString("encoding", "utf8") == codec.Base64 {
+		if data, err = codec.Decode(codec.Base64, content); err != nil {
+			return mcp.NewToolResultErrorf("content: %v", err), nil
+		}
+	}
+	mode := request.GetString("mode", sandbox.WriteOverwrite)
+
+	p, created, err := w.fsys.WriteFile(name, data, mode, request.GetBool("create_dirs", false))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	verb := "wrote"
+	switch {
+	case created:
+		verb = "created"
+	case mode == sandbox.WriteAppend:
+		verb = "appended"
+	}
+	return mcp.NewToolResultStructured(WriteFileResult{
+		Path:    p.String(),
+		Bytes:   len(data),
+		Created: created,
+		Mode:    mode,
+	}, fmt.Sprintf("%s %s (%d bytes)", verb, p, len(data))), nil
+}
@@ -2,8 +2,15 @@
 package manager
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
 	"mcp-go-tutorials/internal/pkg/tool"
+	"mcp-go-tutorials/pkg/log"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -27,11 +34,73 @@ func (tm *Manager) RegisterTool(tool tool.Handler) {
 // RegisterAllTools 注册所有工具到MCP服务器
 func (tm *Manager) RegisterAllTools(s *server.MCPServer) {
 	for _, handler := range tm.tools {
-		s.AddTool(handler.Schema(), handler.Handle)
+		s.AddTool(handler.Schema(), withLogging(handler))
+	}
+}
+
+// withLogging 使用 tool.<name> 模块日志记录工具调用，便于按工具调整日志级别
+func withLogging(handler tool.Handler) server.ToolHandlerFunc {
+	logger := log.Module("tool." + handler.Name())
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if id, ok := tool.ClientIdentityFromContext(ctx); ok {
+			logger.Debugf("Calling tool %s as %s with arguments %v", handler.Name(), id.Subject, describeArguments(request.GetArguments()))
+		} else {
+			logger.Debugf("Calling tool %s with arguments %v", handler.Name(), describeArguments(request.GetArguments()))
+		}
+		result, err := handler.Handle(ctx, request)
+		if err != nil {
+			logger.Errorf("Tool %s failed: %v", handler.Name(), err)
+		} else if result != nil && result.IsError {
+			logger.Debugf("Tool %s returned an error result", handler.Name())
+		}
+		return result, err
 	}
 }
 
+// describeArguments 只记录参数名和取值的类型、长度，不输出取值本身，
+// 避免密钥、文件内容等敏感参数以明文写入日志
+func describeArguments(arguments map[string]any) string {
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		var desc string
+		switch v := arguments[name].(type) {
+		case nil:
+			desc = "null"
+		case string:
+			desc = fmt.Sprintf("string, %d bytes", len(v))
+		case bool:
+			desc = "boolean"
+		case float64, int, int64:
+			desc = "number"
+		case []any:
+			desc = fmt.Sprintf("array, %d items", len(v))
+		case map[string]any:
+			desc = fmt.Sprintf("object, %d keys", len(v))
+		default:
+			desc = fmt.Sprintf("%T", v)
+		}
+		parts = append(parts, fmt.Sprintf("%s(%s)", name, desc))
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
 // GetTools 获取所有工具
 func (tm *Manager) GetTools() []tool.Handler {
 	return tm.tools
 }
+
+// GetTool 按名称获取工具
+func (tm *Manager) GetTool(name string) (tool.Handler, bool) {
+	for _, handler := range tm.tools {
+		if handler.Name() == name {
+			return handler, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,240 @@
+package units
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// units.json 单位表，包含基本量纲、常用物理量、单位和词头
+//
+//go:embed units.json
+var tableJSON []byte
+
+// maxDimensions 单位表中基本量纲的最大个数
+const maxDimensions = 8
+
+// tableFile units.json 的结构
+type tableFile struct {
+	Dimensions []string `json:"dimensions"`
+	Quantities []struct {
+		Name      string         `json:"name"`
+		Dimension map[string]int `json:"dimension"`
+	} `json:"quantities"`
+	Units []struct {
+		Symbol    string         `json:"symbol"`
+		Names     []string       `json:"names"`
+		Dimension map[string]int `json:"dimension"`
+		Factor    ratio          `json:"factor"`
+		Offset    ratio          `json:"offset"`
+		Prefixes  string         `json:"prefixes"`
+	} `json:"units"`
+	Prefixes map[string][]prefix `json:"prefixes"`
+}
+
+// prefix 单位词头，例如 k（kilo）和 Ki（kibi）
+type prefix struct {
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+	Factor ratio  `json:"factor"`
+}
+
+// ratio 精确的换算系数，可以写成 JSON 数字或 "5/9" 这样的分数字符串
+type ratio struct {
+	*big.Rat
+}
+
+func (r *ratio) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	num, den, isFraction := strings.Cut(text, "/")
+	value, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return fmt.Errorf("invalid factor %s", data)
+	}
+	if isFraction {
+		divisor, ok := new(big.Rat).SetString(den)
+		if !ok || divisor.Sign() == 0 {
+			return fmt.Errorf("invalid factor %s", data)
+		}
+		value.Quo(value, divisor)
+	}
+	r.Rat = value
+	return nil
+}
+
+// orZero 返回系数，未设置时返回 0
+func (r ratio) orZero() *big.Rat {
+	if r.Rat == nil {
+		return new(big.Rat)
+	}
+	return r.Rat
+}
+
+// unit 单位表中的单位
+type unit struct {
+	symbol    string
+	dimension Dimension
+	factor    *big.Rat
+	offset    *big.Rat // 为 0 表示没有偏移
+	prefixes  string   // 可以使用的词头类别，为空时不能加词头
+}
+
+// table 加载后的单位表
+type table struct {
+	dimensions []string
+	quantities map[Dimension]string
+	// order 物理量的顺序，用于列出单位
+	order    []string
+	symbols  map[string]*unit // 区分大小写的单位符号
+	names    map[string]*unit // 小写的单位名称和别名
+	prefixes map[string][]prefix
+	units    []*unit
+}
+
+// std 内置的单位表，表格是随程序发布的数据，格式错误时直接 panic
+var std = mustLoad(tableJSON)
+
+func mustLoad(data []byte) *table {
+	t, err := load(data)
+	if err != nil {
+		panic(fmt.Sprintf("units: invalid unit table: %v", err))
+	}
+	return t
+}
+
+func load(data []byte) (*table, error) {
+	var file tableFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if len(file.Dimensions) > maxDimensions {
+		return nil, fmt.Errorf("at most %d dimensions are supported", maxDimensions)
+	}
+
+	t := &table{
+		dimensions: file.Dimensions,
+		quantities: make(map[Dimension]string, len(file.Quantities)),
+		symbols:    make(map[string]*unit, len(file.Units)),
+		names:      make(map[string]*unit),
+		prefixes:   file.Prefixes,
+	}
+	for _, q := range file.Quantities {
+		dim, err := t.dimension(q.Dimension)
+		if err != nil {
+			return nil, fmt.Errorf("quantity %s: %w", q.Name, err)
+		}
+		t.quantities[dim] = q.Name
+		t.order = append(t.order, q.Name)
+	}
+	for _, u := range file.Units {
+		dim, err := t.dimension(u.Dimension)
+		if err != nil {
+			return nil, fmt.Errorf("unit %s: %w", u.Symbol, err)
+		}
+		if u.Factor.Rat == nil || u.Factor.Sign() <= 0 {
+			return nil, fmt.Errorf("unit %s: factor must be positive", u.Symbol)
+		}
+		if _, ok := t.prefixes[u.Prefixes]; u.Prefixes != "" && !ok {
+			return nil, fmt.Errorf("unit %s: unknown prefix set %q", u.Symbol, u.Prefixes)
+		}
+		if _, ok := t.symbols[u.Symbol]; ok {
+			return nil, fmt.Errorf("duplicate unit symbol %s", u.Symbol)
+		}
+		def := &unit{symbol: u.Symbol, dimension: dim, factor: u.Factor.Rat, offset: u.Offset.orZero(), prefixes: u.Prefixes}
+		t.symbols[u.Symbol] = def
+		t.units = append(t.units, def)
+		for _, name := range u.Names {
+			t.names[strings.ToLower(name)] = def
+		}
+	}
+	return t, nil
+}
+
+// dimension 将量纲名称到指数的映射转换为 Dimension
+func (t *table) dimension(exponents map[string]int) (Dimension, error) {
+	var dim Dimension
+	for name, exp := range exponents {
+		i := t.dimensionIndex(name)
+		if i < 0 {
+			return dim, fmt.Errorf("unknown dimension %q", name)
+		}
+		dim[i] = int8(exp)
+	}
+	return dim, nil
+}
+
+func (t *table) dimensionIndex(name string) int {
+	for i, d := range t.dimensions {
+		if d == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookup 查找单个单位，依次尝试单位符号、名称、带词头的符号和名称，以及名称的复数形式
+// 返回的系数为词头的倍数，没有词头时为 nil
+func (t *table) lookup(name string) (*unit, *big.Rat, bool) {
+	if u, ok := t.symbols[name]; ok {
+		return u, nil, true
+	}
+	lower := strings.ToLower(name)
+	if u, ok := t.names[lower]; ok {
+		return u, nil, true
+	}
+	if u, factor, ok := t.lookupPrefixed(name); ok {
+		return u, factor, true
+	}
+	// 复数形式，例如 meters 和 kilometers
+	if singular, ok := strings.CutSuffix(lower, "s"); ok && singular != "" {
+		if u, ok := t.names[singular]; ok {
+			return u, nil, true
+		}
+		if u, factor, ok := t.lookupPrefixed(singular); ok {
+			return u, factor, true
+		}
+	}
+	return nil, nil, false
+}
+
+// lookupPrefixed 查找带词头的单位，词头符号区分大小写，例如 km、MiB；词头名称不区分大小写，例如 kilometer
+func (t *table) lookupPrefixed(name string) (*unit, *big.Rat, bool) {
+	lower := strings.ToLower(name)
+	for set, prefixes := range t.prefixes {
+		for _, p := range prefixes {
+			if rest, ok := strings.CutPrefix(name, p.Symbol); ok && rest != "" {
+				if u := t.prefixable(rest, set); u != nil {
+					return u, p.Factor.Rat, true
+				}
+			}
+			if p.Name == "" {
+				continue
+			}
+			if rest, ok := strings.CutPrefix(lower, p.Name); ok && rest != "" {
+				if u := t.prefixable(rest, set); u != nil {
+					return u, p.Factor.Rat, true
+				}
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// prefixable 查找可以使用指定词头类别的单位
+func (t *table) prefixable(name string, set string) *unit {
+	u, ok := t.symbols[name]
+	if !ok {
+		u, ok = t.names[name]
+	}
+	if ok && u.prefixes == set {
+		return u
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+// Package units 单位换算，支持词头、量纲检查和 km/h 这样的组合单位，单位定义来自嵌入的 units.json
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxExponent 单位指数的最大绝对值
+const maxExponent = 9
+
+// MaxLength 单位表达式的最大字节数，组合单位的项数越多换算系数越大
+const MaxLength = 256
+
+// Dimension 量纲，每个元素是对应基本量纲的指数
+type Dimension [maxDimensions]int8
+
+// String 返回量纲对应的物理量名称，没有名称时返回 length^2·time^-1 形式的表达式
+func (d Dimension) String() string {
+	if name, ok := std.quantities[d]; ok {
+		return name
+	}
+	var parts []string
+	for i, exp := range d {
+		switch {
+		case exp == 0:
+		case exp == 1:
+			parts = append(parts, std.dimensions[i])
+		default:
+			parts = append(parts, std.dimensions[i]+"^"+strconv.Itoa(int(exp)))
+		}
+	}
+	if len(parts) == 0 {
+		return "dimensionless"
+	}
+	return strings.Join(parts, "·")
+}
+
+// Unit 解析后的单位
+type Unit struct {
+	Name      string    // 原始写法
+	Dimension Dimension // 量纲
+	Factor    *big.Rat  // 1 个该单位等于多少个基本单位
+	Offset    *big.Rat  // 乘以 Factor 之前加上的偏移，只有摄氏度和华氏度不为 0
+}
+
+// Quantity 返回单位的物理量名称
+func (u Unit) Quantity() string {
+	return u.Dimension.String()
+}
+
+// Parse 解析单位，支持用 * · / 组合的单位和 ^n、²、³ 形式的指数，例如 km/h、m/s^2、kg·m²
+// 除号只作用于紧随其后的一个单位，kg/m/s 等价于 kg/(m·s)
+func Parse(name string) (Unit, error) {
+	result := Unit{Name: name, Factor: big.NewRat(1, 1), Offset: new(big.Rat)}
+	source := strings.TrimSpace(name)
+	if source == "" {
+		return result, errors.New("unit must not be empty")
+	}
+	if len(source) > MaxLength {
+		return result, fmt.Errorf("unit is %d bytes, the limit is %d", len(source), MaxLength)
+	}
+
+	// 按运算符拆分为单位，sign 为 -1 的单位位于分母
+	var offset *unit
+	terms, sign := 0, 1
+	for {
+		end := strings.IndexAny(source, "*/·⋅")
+		term := source
+		if end >= 0 {
+			term = source[:end]
+		}
+		if err := result.multiply(name, term, sign, &offset); err != nil {
+			return result, err
+		}
+		terms++
+		if end < 0 {
+			break
+		}
+		op, size := utf8.DecodeRuneInString(source[end:])
+		sign = 1
+		if op == '/' {
+			sign = -1
+		}
+		source = source[end+size:]
+	}
+
+	if offset != nil {
+		// 摄氏度和华氏度带有偏移，只能单独使用
+		if terms > 1 {
+			return result, fmt.Errorf("%q: %s cannot be used in a compound unit, use K or °R for temperature differences", name, offset.symbol)
+		}
+		result.Offset.Set(offset.offset)
+	}
+	return result, nil
+}
+
+// multiply 将单个单位及其指数乘到结果中，sign 为 -1 时表示除以该单位
+func (u *Unit) multiply(source string, term string, sign int, offset **unit) error {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return fmt.Errorf("%q: missing unit", source)
+	}
+	// 1/s 中的 1 表示无量纲
+	if term == "1" {
+		return nil
+	}
+
+	name, exp, err := splitExponent(term)
+	if err != nil {
+		return fmt.Errorf("%q: %w", source, err)
+	}
+	def, factor, ok := std.lookup(name)
+	if !ok {
+		return fmt.Errorf("unknown unit %q", name)
+	}
+	if def.offset.Sign() != 0 {
+		if exp != 1 || sign != 1 {
+			return fmt.Errorf("%q: %s cannot be used in a compound unit, use K or °R for temperature differences", source, def.symbol)
+		}
+		*offset = def
+	}
+
+	exp *= sign
+	for i, d := range def.dimension {
+		// 在 int 上累加，防止 int8 溢出后回绕成错误的量纲
+		sum := int(u.Dimension[i]) + int(d)*exp
+		if sum < math.MinInt8 || sum > math.MaxInt8 {
+			return fmt.Errorf("%q: the %s exponent exceeds %d", source, std.dimensions[i], math.MaxInt8)
+		}
+		u.Dimension[i] = int8(sum)
+	}
+	scale := new(big.Rat).Set(def.factor)
+	if factor != nil {
+		scale.Mul(scale, factor)
+	}
+	for range abs(exp) {
+		if exp > 0 {
+			u.Factor.Mul(u.Factor, scale)
+		} else {
+			u.Factor.Quo(u.Factor, scale)
+		}
+	}
+	return nil
+}
+
+// splitExponent 拆分单位和指数，支持 m^2、m^-1、m² 和 m3
+func splitExponent(term string) (string, int, error) {
+	if name, exp, ok := strings.Cut(term, "^"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(exp))
+		if err != nil || n == 0 || abs(n) > maxExponent {
+			return "", 0, fmt.Errorf("invalid exponent %q", exp)
+		}
+		return strings.TrimSpace(name), n, nil
+	}
+	if name, ok := strings.CutSuffix(term, "²"); ok {
+		return name, 2, nil
+	}
+	if name, ok := strings.CutSuffix(term, "³"); ok {
+		return name, 3, nil
+	}
+	// 末尾的数字作为指数，但单位本身存在时优先使用单位，例如 m2 表示平方米
+	last := rune(term[len(term)-1])
+	if len(term) > 1 && unicode.IsDigit(last) && last != '0' && last != '1' {
+		if _, _, ok := std.lookup(term); !ok {
+			return term[:len(term)-1], int(last - '0'), nil
+		}
+	}
+	return term, 1, nil
+}
+
+// Convert 将 value 从 from 单位换算为 to 单位，两者的量纲必须相同
+// 换算使用精确的有理数计算，只在最后舍入一次，因此 -40 °C 恰好等于 -40 °F
+func Convert(value float64, from, to Unit) (float64, error) {
+	if from.Dimension != to.Dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", from.Name, from.Quantity(), to.Name, to.Quantity())
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, errors.New("value must be a finite number")
+	}
+	// 使用最短的十进制表示，0.1 按精确的 1/10 计算
+	exact, _ := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	base := exact.Add(exact, from.Offset).Mul(exact, from.Factor)
+	result, _ := base.Quo(base, to.Factor).Sub(base, to.Offset).Float64()
+	if math.IsInf(result, 0) {
+		return 0, fmt.Errorf("converting %v %s to %s overflows float64", value, from.Name, to.Name)
+	}
+	return result, nil
+}
+
+// Ratio 返回 1 个 from 单位等于多少个 to 单位，带偏移的温度单位之间没有固定倍数，此时 ok 为 false
+func Ratio(from, to Unit) (ratio float64, ok bool) {
+	if from.Dimension != to.Dimension || from.Offset.Sign() != 0 || to.Offset.Sign() != 0 {
+		return 0, false
+	}
+	ratio, _ = new(big.Rat).Quo(from.Factor, to.Factor).Float64()
+	return ratio, true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Group 同一物理量的单位
+type Group struct {
+	Quantity string
+	Symbols  []string
+}
+
+// Catalog 按物理量列出单位表中的所有单位符号，不包含带词头的形式
+func Catalog() []Group {
+	groups := make([]Group, 0, len(std.order))
+	index := make(map[string]int, len(std.order))
+	for _, name := range std.order {
+		index[name] = len(groups)
+		groups = append(groups, Group{Quantity: name})
+	}
+	for _, u := range std.units {
+		name := u.dimension.String()
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, Group{Quantity: name})
+		}
+		groups[i].Symbols = append(groups[i].Symbols, u.symbol)
+	}
+	// 去掉没有单位的物理量，例如只能由组合单位表示的 acceleration
+	result := groups[:0]
+	for _, g := range groups {
+		if len(g.Symbols) > 0 {
+			result = append(result, g)
+		}
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package log
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// callerKey 调用方文件和行号的字段名
+const callerKey = "file"
+
+// loggingPackages 记录调用方时需要跳过的日志相关包
+var loggingPackages = []string{
+	"mcp-go-tutorials/pkg/log.",
+	"github.com/sirupsen/logrus.",
+	"log/slog.",
+	"log.",
+	"runtime.",
+}
+
+// callerHook 为 logrus 日志记录调用方，替代只能跳过 logrus 自身调用栈的 ReportCaller
+type callerHook struct{}
+
+func (h callerHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h callerHook) Fire(entry *logrus.Entry) error {
+	if file := callerFile(); file != "" {
+		entry.Data[callerKey] = file
+	}
+	return nil
+}
+
+// callerFile 返回日志调用方的 "文件名:行号"，跳过日志包自身的调用栈
+func callerFile() string {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isLoggingFrame(frame.Function) {
+			// 只保留文件名，不包含完整路径
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func isLoggingFrame(function string) bool {
+	for _, pkg := range loggingPackages {
+		if strings.HasPrefix(function, pkg) {
+			return true
+		}
+	}
+	return false
+}
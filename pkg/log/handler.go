@@ -0,0 +1,76 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogHandler slog.Handler 适配器，将第三方库通过 slog 输出的日志转发到已配置的日志后端
+type slogHandler struct {
+	module string
+	attrs  []slog.Attr
+	group  string
+}
+
+var _ slog.Handler = &slogHandler{}
+
+// NewSlogHandler 创建 slog.Handler 适配器，module 不为空时使用对应模块的日志级别
+func NewSlogHandler(module string) slog.Handler {
+	return &slogHandler{module: module}
+}
+
+// target 返回当前生效的日志后端
+func (h *slogHandler) target() backend {
+	if h.module != "" {
+		return Module(h.module).(*moduleLogger).current()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return std
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return fromSlogLevel(level) <= h.target().getLevel()
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	record.AddAttrs(h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(h.qualify(a))
+		return true
+	})
+	return h.target().handle(ctx, record)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	c.attrs = append(c.attrs, h.attrs...)
+	for _, a := range attrs {
+		c.attrs = append(c.attrs, h.qualify(a))
+	}
+	return &c
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.group = h.qualifyKey(name)
+	return &c
+}
+
+// qualify 为属性添加分组前缀，分组以 '.' 连接
+func (h *slogHandler) qualify(a slog.Attr) slog.Attr {
+	a.Key = h.qualifyKey(a.Key)
+	return a
+}
+
+func (h *slogHandler) qualifyKey(key string) string {
+	if h.group == "" {
+		return key
+	}
+	return h.group + "." + key
+}
@@ -0,0 +1,241 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// moduleLevels 按模块覆盖的日志级别，模块名以 '.' 分层，例如 tool.calculate
+	moduleLevels = make(map[string]logrus.Level)
+	// modules 已创建的模块日志
+	modules = make(map[string]*moduleLogger)
+)
+
+// moduleHook 为 logrus 模块日志添加 module 字段
+type moduleHook struct {
+	name string
+}
+
+func (h moduleHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h moduleHook) Fire(entry *logrus.Entry) error {
+	entry.Data["module"] = h.name
+	return nil
+}
+
+// moduleLogger 模块日志，全局日志重新初始化后自动切换到新的后端
+type moduleLogger struct {
+	name    string
+	backend atomic.Pointer[backendHolder]
+}
+
+// backendHolder 包装 backend 接口以便原子替换
+type backendHolder struct {
+	backend
+}
+
+var _ Logger = &moduleLogger{}
+
+func (m *moduleLogger) current() backend {
+	return m.backend.Load().backend
+}
+
+// SetLevel 修改全局日志级别，可在运行时调用
+func SetLevel(level string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	std.setLevel(lvl)
+	refreshModuleLevels()
+	return nil
+}
+
+// GetLevel 返回当前的全局日志级别
+func GetLevel() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return std.getLevel().String()
+}
+
+// SetModuleLevels 替换所有模块的日志级别覆盖，参数形如 {"tool.calculate": "debug"}
+func SetModuleLevels(levels map[string]string) error {
+	parsed := make(map[string]logrus.Level, len(levels))
+	for name, level := range levels {
+		lvl, err := logrus.ParseLevel(level)
+		if err != nil {
+			return fmt.Errorf("module %s: %w", name, err)
+		}
+		parsed[name] = lvl
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	moduleLevels = parsed
+	refreshModuleLevels()
+	return nil
+}
+
+// ModuleLevels 返回当前的模块日志级别覆盖
+func ModuleLevels() map[string]string {
+	mu.Lock()
+	defer mu.Unlock()
+	levels := make(map[string]string, len(moduleLevels))
+	for name, lvl := range moduleLevels {
+		levels[name] = lvl.String()
+	}
+	return levels
+}
+
+// ParseModuleLevels 解析 "module=level" 形式的模块日志级别列表
+func ParseModuleLevels(values []string) (map[string]string, error) {
+	levels := make(map[string]string, len(values))
+	for _, value := range values {
+		name, level, ok := strings.Cut(value, "=")
+		name, level = strings.TrimSpace(name), strings.TrimSpace(level)
+		if !ok || name == "" || level == "" {
+			return nil, fmt.Errorf("%q is not in module=level form", value)
+		}
+		if _, err := logrus.ParseLevel(level); err != nil {
+			return nil, fmt.Errorf("module %s: %w", name, err)
+		}
+		levels[name] = level
+	}
+	return levels, nil
+}
+
+// Module 返回指定模块的日志，其级别可以通过 SetModuleLevels 单独覆盖
+func Module(name string) Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	if m, ok := modules[name]; ok {
+		return m
+	}
+
+	m := &moduleLogger{name: name}
+	modules[name] = m
+	rebuildModule(m)
+	return m
+}
+
+// rebuildModules 在全局日志重新初始化后重建所有模块日志，调用方需持有 mu
+func rebuildModules() {
+	for _, m := range modules {
+		rebuildModule(m)
+	}
+}
+
+func rebuildModule(m *moduleLogger) {
+	b := std.child(m.name)
+	b.setLevel(moduleLevel(m.name))
+	m.backend.Store(&backendHolder{backend: b})
+}
+
+// refreshModuleLevels 在级别变化后同步所有模块日志的级别，调用方需持有 mu
+func refreshModuleLevels() {
+	for name, m := range modules {
+		m.current().setLevel(moduleLevel(name))
+	}
+}
+
+// moduleLevel 按最长前缀匹配模块的级别覆盖，未匹配时使用全局级别
+func moduleLevel(name string) logrus.Level {
+	keys := make([]string, 0, len(moduleLevels))
+	for key := range moduleLevels {
+		keys = append(keys, key)
+	}
+	// 更长的模块名优先匹配
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	for _, key := range keys {
+		if name == key || strings.HasPrefix(name, key+".") {
+			return moduleLevels[key]
+		}
+	}
+	return std.getLevel()
+}
+
+func (m *moduleLogger) WithField(key string, value interface{}) {
+	m.current().WithField(key, value)
+}
+
+func (m *moduleLogger) Debug(args ...interface{}) {
+	m.current().Debug(args...)
+}
+
+func (m *moduleLogger) Info(args ...interface{}) {
+	m.current().Info(args...)
+}
+
+func (m *moduleLogger) Warn(args ...interface{}) {
+	m.current().Warn(args...)
+}
+
+func (m *moduleLogger) Error(args ...interface{}) {
+	m.current().Error(args...)
+}
+
+func (m *moduleLogger) Fatal(args ...interface{}) {
+	m.current().Fatal(args...)
+}
+
+func (m *moduleLogger) Panic(args ...interface{}) {
+	m.current().Panic(args...)
+}
+
+func (m *moduleLogger) Debugln(args ...interface{}) {
+	m.current().Debugln(args...)
+}
+
+func (m *moduleLogger) Infoln(args ...interface{}) {
+	m.current().Infoln(args...)
+}
+
+func (m *moduleLogger) Warnln(args ...interface{}) {
+	m.current().Warnln(args...)
+}
+
+func (m *moduleLogger) Errorln(args ...interface{}) {
+	m.current().Errorln(args...)
+}
+
+func (m *moduleLogger) Fatalln(args ...interface{}) {
+	m.current().Fatalln(args...)
+}
+
+func (m *moduleLogger) Panicln(args ...interface{}) {
+	m.current().Panicln(args...)
+}
+
+func (m *moduleLogger) Debugf(format string, args ...interface{}) {
+	m.current().Debugf(format, args...)
+}
+
+func (m *moduleLogger) Infof(format string, args ...interface{}) {
+	m.current().Infof(format, args...)
+}
+
+func (m *moduleLogger) Warnf(format string, args ...interface{}) {
+	m.current().Warnf(format, args...)
+}
+
+func (m *moduleLogger) Errorf(format string, args ...interface{}) {
+	m.current().Errorf(format, args...)
+}
+
+func (m *moduleLogger) Fatalf(format string, args ...interface{}) {
+	m.current().Fatalf(format, args...)
+}
+
+func (m *moduleLogger) Panicf(format string, args ...interface{}) {
+	m.current().Panicf(format, args...)
+}
@@ -1,6 +1,8 @@
 package log
 
 import (
+	"context"
+	"log/slog"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -28,21 +30,42 @@ type Logger interface {
 	Panicf(format string, args ...interface{})
 }
 
+// backend 日志后端，在 Logger 之外提供运行时调整级别、派生模块日志和接收 slog 记录的能力
+type backend interface {
+	Logger
+	setLevel(level logrus.Level)
+	getLevel() logrus.Level
+	// child 派生共享输出和格式的模块日志
+	child(module string) backend
+	// handle 输出来自 slog.Handler 适配器的记录
+	handle(ctx context.Context, r slog.Record) error
+}
+
 type LogrusLogger struct {
 	log *logrus.Logger
 }
 
 // Logger 日志接口
 var _ Logger = &LogrusLogger{}
+var _ backend = &LogrusLogger{}
 var (
 	mu  sync.Mutex
-	std = &LogrusLogger{log: logrus.New()}
+	std backend = &LogrusLogger{log: logrus.New()}
 )
 
 func Init(opts *Options) {
 	mu.Lock()
 	defer mu.Unlock()
-	std = NewLogger(opts)
+	std = newBackend(opts)
+	if opts != nil {
+		if levels, err := ParseModuleLevels(opts.Modules); err == nil {
+			moduleLevels = make(map[string]logrus.Level, len(levels))
+			for name, level := range levels {
+				moduleLevels[name], _ = logrus.ParseLevel(level)
+			}
+		}
+	}
+	rebuildModules()
 }
 
 func NewLogger(opts *Options) *LogrusLogger {
@@ -61,15 +84,71 @@ func NewLogger(opts *Options) *LogrusLogger {
 	}
 	// 设置日志格式
 	setLogFormatter(opts, l)
-	//设置调用者信息
-	l.SetReportCaller(opts.EnableCaller)
+	//设置调用者信息，由 callerHook 跳过本包的调用栈后记录
+	if opts.EnableCaller {
+		l.AddHook(callerHook{})
+	}
 	//设置输出目标
 	setupOutput(opts, l)
 	return &LogrusLogger{log: l}
 }
 
+// newBackend 按 opts.Backend 创建日志后端
+func newBackend(opts *Options) backend {
+	if opts != nil && opts.Backend == BackendSlog {
+		return NewSlogLogger(opts)
+	}
+	return NewLogger(opts)
+}
+
+func (l *LogrusLogger) setLevel(level logrus.Level) {
+	l.log.SetLevel(level)
+}
+
+func (l *LogrusLogger) getLevel() logrus.Level {
+	return l.log.GetLevel()
+}
+
+func (l *LogrusLogger) child(module string) backend {
+	c := logrus.New()
+	c.SetOutput(l.log.Out)
+	c.SetFormatter(l.log.Formatter)
+	c.SetLevel(l.log.GetLevel())
+	// 所有 hook 都注册了 panic 级别；module 字段需要在输出目标的 hook 写入之前添加
+	var outputs []*outputHook
+	for _, hook := range l.log.Hooks[logrus.PanicLevel] {
+		switch h := hook.(type) {
+		case callerHook:
+			c.AddHook(callerHook{})
+		case *outputHook:
+			outputs = append(outputs, h)
+		}
+	}
+	c.AddHook(moduleHook{name: module})
+	for _, h := range outputs {
+		c.AddHook(h)
+	}
+	return &LogrusLogger{log: c}
+}
+
+// handle 输出经由 slog 适配器传入的记录，panic 级别按 fatal 级别输出，
+// 不触发 panic 也不退出进程，这些副作用只属于显式的 Panic*、Fatal* 方法
+func (l *LogrusLogger) handle(_ context.Context, r slog.Record) error {
+	fields := make(logrus.Fields, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		fields[a.Key] = a.Value.Any()
+		return true
+	})
+	level := fromSlogLevel(r.Level)
+	if level < logrus.FatalLevel {
+		level = logrus.FatalLevel
+	}
+	l.log.WithFields(fields).WithTime(r.Time).Log(level, r.Message)
+	return nil
+}
+
 func Debugln(args ...interface{}) {
-	std.log.Debugln(args...)
+	std.Debugln(args...)
 }
 
 func (l LogrusLogger) Debugln(args ...interface{}) {
@@ -77,7 +156,7 @@ func (l LogrusLogger) Debugln(args ...interface{}) {
 }
 
 func Infoln(args ...interface{}) {
-	std.log.Infoln(args...)
+	std.Infoln(args...)
 }
 
 func (l LogrusLogger) Infoln(args ...interface{}) {
@@ -85,7 +164,7 @@ func (l LogrusLogger) Infoln(args ...interface{}) {
 }
 
 func Warnln(args ...interface{}) {
-	std.log.Warnln(args...)
+	std.Warnln(args...)
 }
 
 func (l LogrusLogger) Warnln(args ...interface{}) {
@@ -93,7 +172,7 @@ func (l LogrusLogger) Warnln(args ...interface{}) {
 }
 
 func Errorln(args ...interface{}) {
-	std.log.Errorln(args...)
+	std.Errorln(args...)
 }
 
 func (l LogrusLogger) Errorln(args ...interface{}) {
@@ -101,7 +180,7 @@ func (l LogrusLogger) Errorln(args ...interface{}) {
 }
 
 func Fatalln(args ...interface{}) {
-	std.log.Fatalln(args...)
+	std.Fatalln(args...)
 }
 
 func (l LogrusLogger) Fatalln(args ...interface{}) {
@@ -109,7 +188,7 @@ func (l LogrusLogger) Fatalln(args ...interface{}) {
 }
 
 func Panicln(args ...interface{}) {
-	std.log.Panicln(args...)
+	std.Panicln(args...)
 }
 
 func (l LogrusLogger) Panicln(args ...interface{}) {
@@ -117,7 +196,7 @@ func (l LogrusLogger) Panicln(args ...interface{}) {
 }
 
 func WithField(key string, value interface{}) {
-	std.log.WithField(key, value)
+	std.WithField(key, value)
 }
 
 func (l LogrusLogger) WithField(key string, value interface{}) {
@@ -125,7 +204,7 @@ func (l LogrusLogger) WithField(key string, value interface{}) {
 }
 
 func Info(args ...interface{}) {
-	std.log.Info(args...)
+	std.Info(args...)
 }
 
 func (l LogrusLogger) Info(args ...interface{}) {
@@ -133,7 +212,7 @@ func (l LogrusLogger) Info(args ...interface{}) {
 }
 
 func Debug(args ...interface{}) {
-	std.log.Debug(args...)
+	std.Debug(args...)
 }
 
 func (l LogrusLogger) Debug(args ...interface{}) {
@@ -141,7 +220,7 @@ func (l LogrusLogger) Debug(args ...interface{}) {
 }
 
 func Warn(args ...interface{}) {
-	std.log.Warn(args...)
+	std.Warn(args...)
 }
 
 func (l LogrusLogger) Warn(args ...interface{}) {
@@ -149,7 +228,7 @@ func (l LogrusLogger) Warn(args ...interface{}) {
 }
 
 func Error(args ...interface{}) {
-	std.log.Error(args...)
+	std.Error(args...)
 }
 
 func (l LogrusLogger) Error(args ...interface{}) {
@@ -157,7 +236,7 @@ func (l LogrusLogger) Error(args ...interface{}) {
 }
 
 func Fatal(args ...interface{}) {
-	std.log.Fatal(args...)
+	std.Fatal(args...)
 }
 
 func (l LogrusLogger) Fatal(args ...interface{}) {
@@ -165,7 +244,7 @@ func (l LogrusLogger) Fatal(args ...interface{}) {
 }
 
 func Panic(args ...interface{}) {
-	std.log.Panic(args...)
+	std.Panic(args...)
 }
 
 func (l LogrusLogger) Panic(args ...interface{}) {
@@ -173,7 +252,7 @@ func (l LogrusLogger) Panic(args ...interface{}) {
 }
 
 func Debugf(format string, args ...interface{}) {
-	std.log.Debugf(format, args...)
+	std.Debugf(format, args...)
 }
 
 func (l LogrusLogger) Debugf(format string, args ...interface{}) {
@@ -181,7 +260,7 @@ func (l LogrusLogger) Debugf(format string, args ...interface{}) {
 }
 
 func Infof(format string, args ...interface{}) {
-	std.log.Infof(format, args...)
+	std.Infof(format, args...)
 }
 
 func (l LogrusLogger) Infof(format string, args ...interface{}) {
@@ -189,7 +268,7 @@ func (l LogrusLogger) Infof(format string, args ...interface{}) {
 }
 
 func Warnf(format string, args ...interface{}) {
-	std.log.Warnf(format, args...)
+	std.Warnf(format, args...)
 }
 
 func (l LogrusLogger) Warnf(format string, args ...interface{}) {
@@ -197,7 +276,7 @@ func (l LogrusLogger) Warnf(format string, args ...interface{}) {
 }
 
 func Errorf(format string, args ...interface{}) {
-	std.log.Errorf(format, args...)
+	std.Errorf(format, args...)
 }
 
 func (l LogrusLogger) Errorf(format string, args ...interface{}) {
@@ -205,7 +284,7 @@ func (l LogrusLogger) Errorf(format string, args ...interface{}) {
 }
 
 func Fatalf(format string, args ...interface{}) {
-	std.log.Fatalf(format, args...)
+	std.Fatalf(format, args...)
 }
 
 func (l LogrusLogger) Fatalf(format string, args ...interface{}) {
@@ -213,7 +292,7 @@ func (l LogrusLogger) Fatalf(format string, args ...interface{}) {
 }
 
 func Panicf(format string, args ...interface{}) {
-	std.log.Panicf(format, args...)
+	std.Panicf(format, args...)
 }
 
 func (l LogrusLogger) Panicf(format string, args ...interface{}) {
@@ -5,7 +5,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"runtime"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -21,21 +22,52 @@ const (
 	TimeFormatCompact = "20060102-150405"
 )
 
+// 日志后端
+const (
+	BackendLogrus = "logrus"
+	BackendSlog   = "slog"
+)
+
+// 可选的日志后端、级别、格式、输出目标和时间格式
+var (
+	Backends    = []string{BackendLogrus, BackendSlog}
+	Levels      = []string{"debug", "info", "warn", "error", "panic", "fatal"}
+	Formats     = []string{"json", "text"}
+	Outputs     = []string{"stdout", "stderr", "file", "both"}
+	TimeFormats = []string{"iso8601", "ISO8601", "human", "human-readable", "with-ms", "milliseconds", "compact", "rfc3339", "rfc1123"}
+)
+
 type Options struct {
-	Level         string // debug, info, warn, error, panic, fatal
-	Format        string // json, text
-	Output        string // stdout, file, both
-	TimeFormat    string // iso8601, human, with-ms, etc.
-	Filepath      string // 文件名
-	MaxSize       int    // MB
-	MaxBackups    int    // 备份文件数
-	MaxAge        int    // 备份最大days
-	EnableCaller  bool   // 是否启用文件名和行号
-	DisableStdout bool   // 是否禁用 stdout 输出
+	Backend       string   `mapstructure:"backend"`       // logrus, slog
+	Level         string   `mapstructure:"level"`         // debug, info, warn, error, panic, fatal
+	Format        string   `mapstructure:"format"`        // json, text
+	Output        string   `mapstructure:"output"`        // stdout, stderr, file, both
+	TimeFormat    string   `mapstructure:"timeFormat"`    // iso8601, human, with-ms, etc.
+	Filepath      string   `mapstructure:"filepath"`      // 文件名
+	MaxSize       int      `mapstructure:"maxSize"`       // MB
+	MaxBackups    int      `mapstructure:"maxBackups"`    // 备份文件数
+	MaxAge        int      `mapstructure:"maxAge"`        // 备份最大days
+	EnableCaller  bool     `mapstructure:"enableCaller"`  // 是否启用文件名和行号
+	DisableStdout bool     `mapstructure:"disableStdout"` // 是否禁用 stdout 输出
+	Modules       []string `mapstructure:"modules"`       // 模块日志级别覆盖，形如 tool.calculate=debug
+	// Outputs 输出目标列表，每个目标可以单独设置级别和格式，不为空时替代 output 配置
+	Outputs   []OutputOptions `mapstructure:"outputs"`
+	UseStderr bool            `mapstructure:"-"` // 将控制台输出改为 stderr，用于 stdout 被命令输出占用的场景
+}
+
+// FieldError 单个日志选项的校验错误
+type FieldError struct {
+	Key     string
+	Message string
 }
 
 func NewOptions() *Options {
+	var outputs []OutputOptions
+	if err := viper.UnmarshalKey("log.outputs", &outputs); err != nil {
+		logrus.Warnf("Failed to parse log.outputs: %v", err)
+	}
 	return &Options{
+		Backend:       viper.GetString("log.backend"),
 		Level:         viper.GetString("log.level"),
 		Format:        viper.GetString("log.format"),
 		Output:        viper.GetString("log.output"),
@@ -46,11 +78,14 @@ func NewOptions() *Options {
 		MaxAge:        viper.GetInt("log.maxAge"),
 		EnableCaller:  viper.GetBool("log.enableCaller"),
 		DisableStdout: viper.GetBool("log.disableStdout"),
+		Modules:       viper.GetStringSlice("log.modules"),
+		Outputs:       outputs,
 	}
 }
 
 func NewDefaultOptions() *Options {
 	return &Options{
+		Backend:       BackendLogrus,
 		Level:         "info",
 		Format:        "json",
 		Output:        "both",
@@ -64,6 +99,43 @@ func NewDefaultOptions() *Options {
 	}
 }
 
+// Validate 校验日志选项，返回所有不合法的选项
+func (o *Options) Validate() []FieldError {
+	var errs []FieldError
+	oneOf := func(key string, value string, allowed []string) {
+		if !slices.Contains(allowed, value) {
+			errs = append(errs, FieldError{
+				Key:     key,
+				Message: fmt.Sprintf("unknown value %q, must be one of %s", value, strings.Join(allowed, ", ")),
+			})
+		}
+	}
+	nonNegative := func(key string, value int) {
+		if value < 0 {
+			errs = append(errs, FieldError{Key: key, Message: fmt.Sprintf("%d must not be negative", value)})
+		}
+	}
+
+	oneOf("backend", o.Backend, Backends)
+	oneOf("level", o.Level, Levels)
+	oneOf("format", o.Format, Formats)
+	oneOf("output", o.Output, Outputs)
+	oneOf("timeFormat", o.TimeFormat, TimeFormats)
+	if o.Output == "file" && o.Filepath == "" {
+		errs = append(errs, FieldError{Key: "filepath", Message: "must be set when output is file"})
+	}
+	nonNegative("maxSize", o.MaxSize)
+	nonNegative("maxBackups", o.MaxBackups)
+	nonNegative("maxAge", o.MaxAge)
+	if _, err := ParseModuleLevels(o.Modules); err != nil {
+		errs = append(errs, FieldError{Key: "modules", Message: err.Error()})
+	}
+	for i, output := range o.Outputs {
+		errs = append(errs, validateOutput(fmt.Sprintf("outputs[%d]", i), output)...)
+	}
+	return errs
+}
+
 func getTimeFormat(format string) string {
 	switch format {
 	case "iso8601", "ISO8601":
@@ -84,67 +156,90 @@ func getTimeFormat(format string) string {
 }
 
 func setLogFormatter(opts *Options, l *logrus.Logger) {
-	timeFormat := getTimeFormat(opts.TimeFormat)
-	// 设置格式
-	switch opts.Format {
+	l.SetFormatter(newFormatter(opts.Format, opts.TimeFormat))
+}
+
+// newFormatter 按格式创建 logrus 的 Formatter，输出目标列表中的每个目标也使用它
+func newFormatter(format string, timeFormat string) logrus.Formatter {
+	timestampFormat := getTimeFormat(timeFormat)
+	switch format {
 	case "json":
-		l.SetFormatter(&logrus.JSONFormatter{
-			CallerPrettyfier: getCallerPrettifier(),
-			TimestampFormat:  timeFormat,
-		})
-	case "text":
-		// 判断是否输出到终端（TTY）
-		l.SetFormatter(&logrus.TextFormatter{
-			ForceColors:               false,
-			DisableColors:             true,
-			FullTimestamp:             true,
-			EnvironmentOverrideColors: true,
-			CallerPrettyfier:          getCallerPrettifier(),
-		})
+		return &logrus.JSONFormatter{
+			TimestampFormat: timestampFormat,
+		}
 	default:
-		l.SetFormatter(&logrus.TextFormatter{
+		return &logrus.TextFormatter{
 			FullTimestamp:             true,
-			TimestampFormat:           timeFormat,
+			TimestampFormat:           timestampFormat,
 			ForceColors:               false,
 			DisableColors:             true,
 			EnvironmentOverrideColors: true,
-			CallerPrettyfier:          getCallerPrettifier(),
-		})
+		}
 	}
 }
 
 func setupOutput(opts *Options, l *logrus.Logger) {
+	if len(opts.Outputs) == 0 {
+		replaceSinks(nil)
+		l.SetOutput(newOutput(opts))
+		return
+	}
+	// 配置了输出目标列表时，日志由各目标的 hook 按自己的级别和格式写入
+	l.SetOutput(io.Discard)
+	for _, o := range newOutputs(opts) {
+		l.AddHook(newOutputHook(o, opts.TimeFormat))
+	}
+}
+
+// newOutput 根据配置创建日志输出目标，logrus 和 slog 后端共用
+func newOutput(opts *Options) io.Writer {
 	var writers []io.Writer
 	// 根据配置添加输出目标
 	switch opts.Output {
 	case "stdout":
 		if !opts.DisableStdout {
-			writers = append(writers, os.Stdout)
+			writers = append(writers, consoleOutput(opts))
 		}
+	case "stderr":
+		writers = append(writers, os.Stderr)
 	case "file":
 		if opts.Filepath != "" {
 			fileOutput := setupFileOutput(opts)
 			writers = append(writers, fileOutput)
 		}
 	case "both", "":
-		writers = append(writers, os.Stdout)
+		if !opts.DisableStdout {
+			writers = append(writers, consoleOutput(opts))
+		}
 		if opts.Filepath != "" {
 			fileOutput := setupFileOutput(opts)
 			writers = append(writers, fileOutput)
 		}
 	}
-	if len(writers) > 1 {
-		mw := io.MultiWriter(writers...)
-		l.SetOutput(mw)
+	switch len(writers) {
+	case 0:
+		return io.Discard
+	case 1:
+		return writers[0]
+	default:
+		return io.MultiWriter(writers...)
+	}
+}
+
+// consoleOutput 返回控制台输出目标
+func consoleOutput(opts *Options) io.Writer {
+	if opts.UseStderr {
+		return os.Stderr
 	}
+	return os.Stdout
 }
 
 func setupFileOutput(cfg *Options) io.Writer {
 	// 确保日志目录存在
 	dir := filepath.Dir(cfg.Filepath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		logrus.Warnf("Failed to create log directory: %v, using stdout only", err)
-		return os.Stdout
+		logrus.Warnf("Failed to create log directory: %v, using console only", err)
+		return consoleOutput(cfg)
 	}
 	return &lumberjack.Logger{
 		Filename:   cfg.Filepath,
@@ -155,11 +250,3 @@ func setupFileOutput(cfg *Options) io.Writer {
 		LocalTime:  true,           // 使用本地时间
 	}
 }
-
-func getCallerPrettifier() func(*runtime.Frame) (function string, file string) {
-	return func(frame *runtime.Frame) (function string, file string) {
-		// 只保留文件名，不包含完整路径
-		_, filename := filepath.Split(frame.File)
-		return "", fmt.Sprintf("%s:%d", filename, frame.Line)
-	}
-}
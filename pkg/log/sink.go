@@ -0,0 +1,310 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 输出目标类型
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+	OutputSyslog = "syslog"
+	OutputTCP    = "tcp"
+	OutputUDP    = "udp"
+	OutputHTTP   = "http"
+)
+
+// OutputTypes 可选的输出目标类型
+var OutputTypes = []string{OutputStdout, OutputStderr, OutputFile, OutputSyslog, OutputTCP, OutputUDP, OutputHTTP}
+
+// OutputOptions 单个输出目标的配置，Level 和 Format 为空时使用全局配置
+type OutputOptions struct {
+	Type          string        `mapstructure:"type"`          // stdout, stderr, file, syslog, tcp, udp, http
+	Level         string        `mapstructure:"level"`         // 该输出的最低级别，只能在全局级别的基础上进一步过滤
+	Format        string        `mapstructure:"format"`        // json, text
+	Address       string        `mapstructure:"address"`       // syslog: unix:///dev/log 或 udp://host:514；tcp/udp: host:port；http: URL
+	Filepath      string        `mapstructure:"filepath"`      // file 输出的文件名，为空时使用全局 filepath
+	Tag           string        `mapstructure:"tag"`           // syslog 的 tag
+	Timeout       time.Duration `mapstructure:"timeout"`       // 网络写入和 HTTP 请求的超时时间
+	BufferSize    int           `mapstructure:"bufferSize"`    // http 输出的缓冲条数，缓冲满时丢弃
+	BatchSize     int           `mapstructure:"batchSize"`     // http 输出每批发送的最大条数
+	FlushInterval time.Duration `mapstructure:"flushInterval"` // http 输出的最长发送间隔
+	MaxRetries    int           `mapstructure:"maxRetries"`    // http 输出单批的最大重试次数
+}
+
+// Sink 日志输出目标，level 为当前写入的日志级别
+type Sink interface {
+	WriteLevel(level logrus.Level, p []byte) error
+	Close() error
+}
+
+// SinkStats 输出目标的统计信息
+type SinkStats struct {
+	Name    string `json:"name"`
+	Written uint64 `json:"written"`
+	Dropped uint64 `json:"dropped"`
+	Failed  uint64 `json:"failed"`
+}
+
+// sinkCounters 输出目标的计数器
+type sinkCounters struct {
+	name    string
+	written atomic.Uint64
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+func (c *sinkCounters) stats() SinkStats {
+	return SinkStats{
+		Name:    c.name,
+		Written: c.written.Load(),
+		Dropped: c.dropped.Load(),
+		Failed:  c.failed.Load(),
+	}
+}
+
+// statsProvider 提供统计信息的输出目标
+type statsProvider interface {
+	stats() SinkStats
+}
+
+var (
+	sinksMu sync.Mutex
+	// sinks 当前日志使用的输出目标，重新初始化时关闭
+	sinks []Sink
+)
+
+func init() {
+	// fatal 日志退出进程前发送缓冲中的日志
+	logrus.RegisterExitHandler(func() { _ = Close() })
+}
+
+// Stats 返回当前所有输出目标的统计信息
+func Stats() []SinkStats {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	stats := make([]SinkStats, 0, len(sinks))
+	for _, s := range sinks {
+		if p, ok := s.(statsProvider); ok {
+			stats = append(stats, p.stats())
+		}
+	}
+	return stats
+}
+
+// Close 关闭所有输出目标，发送缓冲中的日志
+func Close() error {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	sinks = nil
+	if len(errs) > 0 {
+		return fmt.Errorf("close log outputs: %v", errs)
+	}
+	return nil
+}
+
+// replaceSinks 替换当前的输出目标并关闭旧的
+func replaceSinks(next []Sink) {
+	sinksMu.Lock()
+	prev := sinks
+	sinks = next
+	sinksMu.Unlock()
+	for _, s := range prev {
+		_ = s.Close()
+	}
+}
+
+// output 已创建的输出目标及其级别和格式
+type output struct {
+	sink   Sink
+	level  logrus.Level
+	format string
+}
+
+// newOutputs 按 opts.Outputs 创建所有输出目标，创建失败的输出会被跳过并记录到 stderr
+func newOutputs(opts *Options) []output {
+	outputs := make([]output, 0, len(opts.Outputs))
+	for i, o := range opts.Outputs {
+		sink, err := newSink(o, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "log: skip output %d (%s): %v\n", i, o.Type, err)
+			continue
+		}
+		level := logrus.TraceLevel
+		if o.Level != "" {
+			level, _ = logrus.ParseLevel(o.Level)
+		}
+		format := o.Format
+		if format == "" {
+			format = opts.Format
+		}
+		outputs = append(outputs, output{sink: sink, level: level, format: format})
+	}
+
+	next := make([]Sink, 0, len(outputs))
+	for _, o := range outputs {
+		next = append(next, o.sink)
+	}
+	replaceSinks(next)
+	return outputs
+}
+
+func newSink(o OutputOptions, opts *Options) (Sink, error) {
+	switch o.Type {
+	case OutputStdout:
+		return newWriterSink(o.Type, consoleOutput(opts), nil), nil
+	case OutputStderr:
+		return newWriterSink(o.Type, os.Stderr, nil), nil
+	case OutputFile:
+		fileOpts := *opts
+		if o.Filepath != "" {
+			fileOpts.Filepath = o.Filepath
+		}
+		w := setupFileOutput(&fileOpts)
+		closer, _ := w.(io.Closer)
+		return newWriterSink("file:"+fileOpts.Filepath, w, closer), nil
+	case OutputSyslog:
+		return newSyslogSink(o)
+	case OutputTCP, OutputUDP:
+		return newNetSink(o), nil
+	case OutputHTTP:
+		format := o.Format
+		if format == "" {
+			format = opts.Format
+		}
+		return newHTTPSink(o, format), nil
+	default:
+		return nil, fmt.Errorf("unknown output type %q", o.Type)
+	}
+}
+
+// validateOutput 校验单个输出目标的配置，prefix 为该目标在配置中的键名
+func validateOutput(prefix string, o OutputOptions) []FieldError {
+	var errs []FieldError
+	add := func(key string, message string) {
+		errs = append(errs, FieldError{Key: prefix + "." + key, Message: message})
+	}
+	oneOf := func(key string, value string, allowed []string) {
+		if !slices.Contains(allowed, value) {
+			add(key, fmt.Sprintf("unknown value %q, must be one of %s", value, strings.Join(allowed, ", ")))
+		}
+	}
+
+	oneOf("type", o.Type, OutputTypes)
+	if o.Level != "" {
+		oneOf("level", o.Level, Levels)
+	}
+	if o.Format != "" {
+		oneOf("format", o.Format, Formats)
+	}
+	switch o.Type {
+	case OutputTCP, OutputUDP, OutputHTTP:
+		if o.Address == "" {
+			add("address", "must be set for "+o.Type+" output")
+		}
+	case OutputSyslog:
+		if o.Address != "" {
+			if _, _, err := parseSyslogAddress(o.Address); err != nil {
+				add("address", err.Error())
+			}
+		}
+	}
+	for _, field := range []struct {
+		key   string
+		value int
+	}{{"bufferSize", o.BufferSize}, {"batchSize", o.BatchSize}, {"maxRetries", o.MaxRetries}} {
+		if field.value < 0 {
+			add(field.key, fmt.Sprintf("%d must not be negative", field.value))
+		}
+	}
+	return errs
+}
+
+// parseSyslogAddress 解析 syslog 地址，支持 unix:///dev/log、udp://host:514 和 tcp://host:514
+func parseSyslogAddress(address string) (network string, addr string, err error) {
+	scheme, rest, ok := strings.Cut(address, "://")
+	if !ok || rest == "" {
+		return "", "", fmt.Errorf("%q must be in scheme://address form", address)
+	}
+	switch scheme {
+	case "unix":
+		return "unixgram", rest, nil
+	case "udp", "tcp":
+		return scheme, rest, nil
+	default:
+		return "", "", fmt.Errorf("unsupported syslog scheme %q, must be unix, udp or tcp", scheme)
+	}
+}
+
+// writerSink 基于 io.Writer 的输出目标
+type writerSink struct {
+	sinkCounters
+	w      io.Writer
+	closer io.Closer
+}
+
+func newWriterSink(name string, w io.Writer, closer io.Closer) *writerSink {
+	s := &writerSink{w: w, closer: closer}
+	s.name = name
+	return s
+}
+
+func (s *writerSink) WriteLevel(_ logrus.Level, p []byte) error {
+	if _, err := s.w.Write(p); err != nil {
+		s.failed.Add(1)
+		return err
+	}
+	s.written.Add(1)
+	return nil
+}
+
+func (s *writerSink) Close() error {
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
+
+// outputHook 将 logrus 日志按输出目标自己的级别和格式写入
+type outputHook struct {
+	output    output
+	formatter logrus.Formatter
+}
+
+func newOutputHook(o output, timeFormat string) *outputHook {
+	return &outputHook{output: o, formatter: newFormatter(o.format, timeFormat)}
+}
+
+func (h *outputHook) Levels() []logrus.Level {
+	levels := make([]logrus.Level, 0, len(logrus.AllLevels))
+	for _, level := range logrus.AllLevels {
+		if level <= h.output.level {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+func (h *outputHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	return h.output.sink.WriteLevel(entry.Level, line)
+}
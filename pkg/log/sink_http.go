@@ -0,0 +1,177 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HTTP 输出的默认缓冲、批量和重试配置
+const (
+	defaultHTTPBufferSize    = 1024
+	defaultHTTPBatchSize     = 100
+	defaultHTTPFlushInterval = time.Second
+	defaultHTTPMaxRetries    = 3
+)
+
+// httpSink 缓冲日志并按批 POST 到 HTTP 收集端，每行一条
+// 缓冲满或重试耗尽时丢弃日志并计数，发送在后台进行，不会阻塞调用方
+type httpSink struct {
+	sinkCounters
+	url           string
+	contentType   string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	entries       chan []byte
+	quit          chan struct{}
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+func newHTTPSink(o OutputOptions, format string) *httpSink {
+	s := &httpSink{
+		url:           o.Address,
+		contentType:   "text/plain; charset=utf-8",
+		client:        &http.Client{Timeout: o.Timeout},
+		batchSize:     o.BatchSize,
+		flushInterval: o.FlushInterval,
+		maxRetries:    o.MaxRetries,
+		quit:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	s.name = "http:" + o.Address
+	if format == "json" {
+		s.contentType = "application/x-ndjson"
+	}
+	if s.client.Timeout <= 0 {
+		s.client.Timeout = defaultSinkTimeout
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultHTTPBatchSize
+	}
+	if s.flushInterval <= 0 {
+		s.flushInterval = defaultHTTPFlushInterval
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = defaultHTTPMaxRetries
+	}
+	bufferSize := o.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultHTTPBufferSize
+	}
+	s.entries = make(chan []byte, bufferSize)
+
+	go s.run()
+	return s
+}
+
+func (s *httpSink) WriteLevel(_ logrus.Level, p []byte) error {
+	select {
+	case <-s.quit:
+		s.dropped.Add(1)
+		return nil
+	default:
+	}
+	select {
+	case s.entries <- p:
+	default:
+		s.dropped.Add(1)
+	}
+	return nil
+}
+
+// run 收集日志并在达到批量大小或发送间隔时发送
+func (s *httpSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, s.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			s.send(batch)
+			batch = make([][]byte, 0, s.batchSize)
+		}
+	}
+	for {
+		select {
+		case p := <-s.entries:
+			batch = append(batch, p)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.quit:
+			// 发送缓冲中剩余的日志后退出
+			for {
+				select {
+				case p := <-s.entries:
+					batch = append(batch, p)
+					if len(batch) >= s.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// send 发送一批日志，失败时按退避时间重试，关闭期间只尝试一次
+func (s *httpSink) send(batch [][]byte) {
+	body := bytes.Join(batch, nil)
+	var backoff time.Duration
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff = nextBackoff(backoff)
+			select {
+			case <-time.After(backoff):
+			case <-s.quit:
+				s.dropped.Add(uint64(len(batch)))
+				return
+			}
+		}
+		if err := s.post(body); err == nil {
+			s.written.Add(uint64(len(batch)))
+			return
+		}
+		s.failed.Add(1)
+	}
+	s.dropped.Add(uint64(len(batch)))
+}
+
+func (s *httpSink) post(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", s.contentType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Close 停止接收日志并等待缓冲中的日志发送完成
+func (s *httpSink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.quit)
+	})
+	<-s.done
+	return nil
+}
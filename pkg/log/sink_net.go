@@ -0,0 +1,101 @@
+package log
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 网络输出的默认超时时间和重连退避时间
+const (
+	defaultSinkTimeout = 5 * time.Second
+	minSinkBackoff     = 500 * time.Millisecond
+	maxSinkBackoff     = 30 * time.Second
+)
+
+// nextBackoff 返回下一次重试的退避时间，按指数增长
+func nextBackoff(d time.Duration) time.Duration {
+	if d < minSinkBackoff {
+		return minSinkBackoff
+	}
+	return min(d*2, maxSinkBackoff)
+}
+
+// netSink 以每行一条的形式将日志写入 TCP 或 UDP 连接
+// 连接断开后按退避时间重连，期间的日志直接丢弃并计数，不会阻塞调用方
+type netSink struct {
+	sinkCounters
+	mu      sync.Mutex
+	network string
+	address string
+	timeout time.Duration
+	conn    net.Conn
+	backoff time.Duration
+	retryAt time.Time
+}
+
+func newNetSink(o OutputOptions) *netSink {
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = defaultSinkTimeout
+	}
+	s := &netSink{network: o.Type, address: o.Address, timeout: timeout}
+	s.name = o.Type + ":" + o.Address
+	return s
+}
+
+func (s *netSink) WriteLevel(_ logrus.Level, p []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if time.Now().Before(s.retryAt) {
+			s.dropped.Add(1)
+			return nil
+		}
+		conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+		if err != nil {
+			s.fail(err)
+			return nil
+		}
+		s.conn = conn
+		s.backoff = 0
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	if _, err := s.conn.Write(p); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		s.fail(err)
+		return nil
+	}
+	s.written.Add(1)
+	return nil
+}
+
+// fail 记录一次写入失败并推迟下一次连接，调用方需持有 mu
+func (s *netSink) fail(err error) {
+	s.failed.Add(1)
+	s.dropped.Add(1)
+	// 只在第一次失败时提示，避免每条日志都输出错误
+	if s.backoff == 0 {
+		fmt.Fprintf(os.Stderr, "log: output %s unavailable, dropping logs: %v\n", s.name, err)
+	}
+	s.backoff = nextBackoff(s.backoff)
+	s.retryAt = time.Now().Add(s.backoff)
+}
+
+func (s *netSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,65 @@
+//go:build !windows && !plan9
+
+package log
+
+import (
+	"log/syslog"
+
+	"github.com/sirupsen/logrus"
+)
+
+// syslogSink 将日志写入本地或远程 syslog，按日志级别设置 syslog 优先级
+type syslogSink struct {
+	sinkCounters
+	w *syslog.Writer
+}
+
+// newSyslogSink 连接 syslog，address 为空时使用本地 syslog
+func newSyslogSink(o OutputOptions) (Sink, error) {
+	var network, addr string
+	if o.Address != "" {
+		var err error
+		if network, addr, err = parseSyslogAddress(o.Address); err != nil {
+			return nil, err
+		}
+	}
+	w, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_USER, o.Tag)
+	if err != nil {
+		return nil, err
+	}
+	s := &syslogSink{w: w}
+	s.name = "syslog"
+	if o.Address != "" {
+		s.name += ":" + o.Address
+	}
+	return s, nil
+}
+
+func (s *syslogSink) WriteLevel(level logrus.Level, p []byte) error {
+	var err error
+	msg := string(p)
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		err = s.w.Crit(msg)
+	case logrus.ErrorLevel:
+		err = s.w.Err(msg)
+	case logrus.WarnLevel:
+		err = s.w.Warning(msg)
+	case logrus.InfoLevel:
+		err = s.w.Info(msg)
+	default:
+		err = s.w.Debug(msg)
+	}
+	// syslog.Writer 写入失败时已经重连过一次，日志直接丢弃
+	if err != nil {
+		s.failed.Add(1)
+		s.dropped.Add(1)
+		return nil
+	}
+	s.written.Add(1)
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,12 @@
+//go:build windows || plan9
+
+package log
+
+import (
+	"errors"
+)
+
+// newSyslogSink 当前平台不支持 log/syslog
+func newSyslogSink(_ OutputOptions) (Sink, error) {
+	return nil, errors.New("syslog output is not supported on this platform")
+}
@@ -0,0 +1,375 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// slog 没有 fatal 和 panic 级别，按 slog 的级别间隔扩展
+const (
+	slogLevelTrace = slog.Level(-8)
+	slogLevelFatal = slog.Level(12)
+	slogLevelPanic = slog.Level(16)
+)
+
+// SlogLogger 基于 log/slog 的日志实现，输出格式与 LogrusLogger 保持一致
+type SlogLogger struct {
+	handler slog.Handler
+	level   *slog.LevelVar
+	outputs []slogOutput
+	opts    *Options
+}
+
+// slogOutput slog 后端的单个输出目标
+type slogOutput struct {
+	w      io.Writer
+	format string
+	// min 该目标的最低级别，与全局级别共同决定是否输出
+	min slog.Level
+	// sink 不为空时写入前需要记录当前日志的级别
+	sink *sinkWriter
+}
+
+var _ Logger = &SlogLogger{}
+var _ backend = &SlogLogger{}
+
+// NewSlogLogger 创建基于 log/slog 的日志
+func NewSlogLogger(opts *Options) *SlogLogger {
+	if opts == nil {
+		opts = NewDefaultOptions()
+	}
+
+	level := new(slog.LevelVar)
+	// 与 NewLogger 一致，无法解析的级别使用 info
+	if lvl, err := logrus.ParseLevel(opts.Level); err == nil {
+		level.Set(toSlogLevel(lvl))
+	}
+	return newSlogLogger(newSlogOutputs(opts), opts, level, nil)
+}
+
+// newSlogOutputs 按配置创建输出目标，未配置输出目标列表时使用 output 配置
+func newSlogOutputs(opts *Options) []slogOutput {
+	if len(opts.Outputs) == 0 {
+		replaceSinks(nil)
+		return []slogOutput{{w: newOutput(opts), format: opts.Format, min: slogLevelTrace}}
+	}
+	var outputs []slogOutput
+	for _, o := range newOutputs(opts) {
+		w := &sinkWriter{sink: o.sink}
+		outputs = append(outputs, slogOutput{w: w, format: o.format, min: toSlogLevel(o.level), sink: w})
+	}
+	return outputs
+}
+
+func newSlogLogger(outputs []slogOutput, opts *Options, level *slog.LevelVar, attrs []slog.Attr) *SlogLogger {
+	handlers := make([]slog.Handler, 0, len(outputs))
+	for _, o := range outputs {
+		handler := newSlogOutputHandler(o, opts, level)
+		if len(attrs) > 0 {
+			handler = handler.WithAttrs(attrs)
+		}
+		handlers = append(handlers, handler)
+	}
+
+	var handler slog.Handler
+	if len(handlers) == 1 {
+		handler = handlers[0]
+	} else {
+		handler = &fanoutHandler{handlers: handlers}
+	}
+	return &SlogLogger{handler: handler, level: level, outputs: outputs, opts: opts}
+}
+
+// newSlogOutputHandler 按输出目标的格式和级别创建 slog.Handler
+func newSlogOutputHandler(o slogOutput, opts *Options, level *slog.LevelVar) slog.Handler {
+	timeFormat := getTimeFormat(opts.TimeFormat)
+	var leveler slog.Leveler = level
+	if o.min > slogLevelTrace {
+		leveler = outputLeveler{global: level, min: o.min}
+	}
+	handlerOpts := &slog.HandlerOptions{
+		Level: leveler,
+		// 与 logrus 的输出保持相同的时间格式和级别名称
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) > 0 {
+				return a
+			}
+			switch a.Key {
+			case slog.TimeKey:
+				return slog.String(slog.TimeKey, a.Value.Time().Format(timeFormat))
+			case slog.LevelKey:
+				if lvl, ok := a.Value.Any().(slog.Level); ok {
+					return slog.String(slog.LevelKey, fromSlogLevel(lvl).String())
+				}
+			}
+			return a
+		},
+	}
+
+	var handler slog.Handler
+	if o.format == "json" {
+		handler = slog.NewJSONHandler(o.w, handlerOpts)
+	} else {
+		handler = slog.NewTextHandler(o.w, handlerOpts)
+	}
+	if o.sink != nil {
+		handler = &sinkHandler{Handler: handler, w: o.sink}
+	}
+	return handler
+}
+
+func (l *SlogLogger) setLevel(level logrus.Level) {
+	l.level.Set(toSlogLevel(level))
+}
+
+func (l *SlogLogger) getLevel() logrus.Level {
+	return fromSlogLevel(l.level.Level())
+}
+
+func (l *SlogLogger) child(module string) backend {
+	level := new(slog.LevelVar)
+	level.Set(l.level.Level())
+	return newSlogLogger(l.outputs, l.opts, level, []slog.Attr{slog.String("module", module)})
+}
+
+func (l *SlogLogger) handle(ctx context.Context, r slog.Record) error {
+	if l.opts.EnableCaller {
+		if file := callerFile(); file != "" {
+			r.AddAttrs(slog.String(callerKey, file))
+		}
+	}
+	return l.handler.Handle(ctx, r)
+}
+
+// output 输出一条日志，fatal 级别退出进程，panic 级别触发 panic
+func (l *SlogLogger) output(level slog.Level, msg string) {
+	ctx := context.Background()
+	if l.handler.Enabled(ctx, level) {
+		_ = l.handle(ctx, slog.NewRecord(time.Now(), level, msg, 0))
+	}
+	switch level {
+	case slogLevelFatal:
+		_ = Close()
+		os.Exit(1)
+	case slogLevelPanic:
+		panic(msg)
+	}
+}
+
+// outputLeveler 输出目标的级别，取全局级别和目标级别中较高的一个
+type outputLeveler struct {
+	global *slog.LevelVar
+	min    slog.Level
+}
+
+func (l outputLeveler) Level() slog.Level {
+	return max(l.global.Level(), l.min)
+}
+
+// sinkWriter 将 slog.Handler 的输出写入 Sink，写入前由 sinkHandler 设置日志级别
+type sinkWriter struct {
+	mu    sync.Mutex
+	sink  Sink
+	level logrus.Level
+}
+
+func (w *sinkWriter) Write(p []byte) (int, error) {
+	// slog.Handler 返回后会复用 p，Sink 可能异步发送，需要复制一份
+	if err := w.sink.WriteLevel(w.level, bytes.Clone(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// sinkHandler 在输出前记录日志级别，同一输出目标的写入串行执行
+type sinkHandler struct {
+	slog.Handler
+	w *sinkWriter
+}
+
+func (h *sinkHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	h.w.level = fromSlogLevel(r.Level)
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *sinkHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sinkHandler{Handler: h.Handler.WithAttrs(attrs), w: h.w}
+}
+
+func (h *sinkHandler) WithGroup(name string) slog.Handler {
+	return &sinkHandler{Handler: h.Handler.WithGroup(name), w: h.w}
+}
+
+// fanoutHandler 将记录分发到多个输出目标
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, r.Level) {
+			if err := handler.Handle(ctx, r.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+// sprintln 与 logrus 的 *ln 方法一致，参数之间总是添加空格
+func sprintln(args ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
+
+// toSlogLevel 将 logrus 级别转换为 slog 级别
+func toSlogLevel(level logrus.Level) slog.Level {
+	switch level {
+	case logrus.TraceLevel:
+		return slogLevelTrace
+	case logrus.DebugLevel:
+		return slog.LevelDebug
+	case logrus.InfoLevel:
+		return slog.LevelInfo
+	case logrus.WarnLevel:
+		return slog.LevelWarn
+	case logrus.ErrorLevel:
+		return slog.LevelError
+	case logrus.FatalLevel:
+		return slogLevelFatal
+	default:
+		return slogLevelPanic
+	}
+}
+
+// fromSlogLevel 将 slog 级别转换为 logrus 级别
+func fromSlogLevel(level slog.Level) logrus.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return logrus.TraceLevel
+	case level < slog.LevelInfo:
+		return logrus.DebugLevel
+	case level < slog.LevelWarn:
+		return logrus.InfoLevel
+	case level < slog.LevelError:
+		return logrus.WarnLevel
+	case level < slogLevelFatal:
+		return logrus.ErrorLevel
+	case level < slogLevelPanic:
+		return logrus.FatalLevel
+	default:
+		return logrus.PanicLevel
+	}
+}
+
+// WithField 与 LogrusLogger 保持一致：接口不返回新的日志对象，字段不会保留
+func (l *SlogLogger) WithField(_ string, _ interface{}) {}
+
+func (l *SlogLogger) Debug(args ...interface{}) {
+	l.output(slog.LevelDebug, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Info(args ...interface{}) {
+	l.output(slog.LevelInfo, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Warn(args ...interface{}) {
+	l.output(slog.LevelWarn, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Error(args ...interface{}) {
+	l.output(slog.LevelError, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Fatal(args ...interface{}) {
+	l.output(slogLevelFatal, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Panic(args ...interface{}) {
+	l.output(slogLevelPanic, fmt.Sprint(args...))
+}
+
+func (l *SlogLogger) Debugln(args ...interface{}) {
+	l.output(slog.LevelDebug, sprintln(args...))
+}
+
+func (l *SlogLogger) Infoln(args ...interface{}) {
+	l.output(slog.LevelInfo, sprintln(args...))
+}
+
+func (l *SlogLogger) Warnln(args ...interface{}) {
+	l.output(slog.LevelWarn, sprintln(args...))
+}
+
+func (l *SlogLogger) Errorln(args ...interface{}) {
+	l.output(slog.LevelError, sprintln(args...))
+}
+
+func (l *SlogLogger) Fatalln(args ...interface{}) {
+	l.output(slogLevelFatal, sprintln(args...))
+}
+
+func (l *SlogLogger) Panicln(args ...interface{}) {
+	l.output(slogLevelPanic, sprintln(args...))
+}
+
+func (l *SlogLogger) Debugf(format string, args ...interface{}) {
+	l.output(slog.LevelDebug, fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Infof(format string, args ...interface{}) {
+	l.output(slog.LevelInfo, fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Warnf(format string, args ...interface{}) {
+	l.output(slog.LevelWarn, fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Errorf(format string, args ...interface{}) {
+	l.output(slog.LevelError, fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Fatalf(format string, args ...interface{}) {
+	l.output(slogLevelFatal, fmt.Sprintf(format, args...))
+}
+
+func (l *SlogLogger) Panicf(format string, args ...interface{}) {
+	l.output(slogLevelPanic, fmt.Sprintf(format, args...))
+}